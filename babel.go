@@ -0,0 +1,180 @@
+package birdparse
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+func ParseBabelInterfaces(data string) []BabelInterface {
+	interfaces := []BabelInterface{}
+	var protocol string
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if strings.HasPrefix(line, "BIRD") ||
+			strings.HasPrefix(line, "Access restricted") ||
+			strings.HasPrefix(line, "Interface") {
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(\S+):$`).FindStringSubmatch(line); m != nil {
+			protocol = m[1]
+			continue
+		}
+
+		m := regexp.MustCompile(`^(\S+)\s+(Up|Down)\s+(?:(Yes|No|Perm)\s+)?(\d+)\s+(\d+)\s+([\d.]+)\s+(\S+)\s+(\S+)$`).FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		iface := BabelInterface{
+			Protocol:    protocol,
+			Interface:   m[1],
+			State:       m[2],
+			Auth:        m[3],
+			RxCost:      atoi(m[4]),
+			Neighbors:   atoi(m[5]),
+			NextHopIPv4: m[7],
+			NextHopIPv6: m[8],
+		}
+		iface.Timer, _ = strconv.ParseFloat(m[6], 64)
+
+		interfaces = append(interfaces, iface)
+	}
+
+	return interfaces
+}
+
+func ParseBabelNeighbors(data string) []BabelNeighbor {
+	neighbors := []BabelNeighbor{}
+	var protocol string
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if strings.HasPrefix(line, "BIRD") ||
+			strings.HasPrefix(line, "Access restricted") ||
+			strings.HasPrefix(line, "IP address") {
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(\S+):$`).FindStringSubmatch(line); m != nil {
+			protocol = m[1]
+			continue
+		}
+
+		m := regexp.MustCompile(`^([0-9a-fA-F.:]+)\s+(\S+)\s+(\d+)\s+(\d+)\s+(\d+)\s+([\d.]+)(?:\s+(\S+))?$`).FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+
+		neighbor := BabelNeighbor{
+			Protocol:  protocol,
+			Address:   m[1],
+			Interface: m[2],
+			Metric:    atoi(m[3]),
+			Routes:    atoi(m[4]),
+			Hellos:    atoi(m[5]),
+			Auth:      m[7],
+		}
+		neighbor.Expires, _ = strconv.ParseFloat(m[6], 64)
+
+		neighbors = append(neighbors, neighbor)
+	}
+
+	return neighbors
+}
+
+func ParseBabelEntries(data string) []BabelEntry {
+	entries := []BabelEntry{}
+	var protocol string
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if strings.HasPrefix(line, "BIRD") ||
+			strings.HasPrefix(line, "Access restricted") ||
+			strings.HasPrefix(line, "Prefix") {
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(\S+):$`).FindStringSubmatch(line); m != nil {
+			protocol = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(\S+/\d+)\s+(\S+)\s+(\d+)\s+(\d+)\s+(\d+)\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+			entries = append(entries, BabelEntry{
+				Protocol: protocol,
+				Prefix:   m[1],
+				RouterID: m[2],
+				Metric:   atoi(m[3]),
+				Seqno:    atoi(m[4]),
+				Routes:   atoi(m[5]),
+				Sources:  atoi(m[6]),
+			})
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(\S+/\d+)\s+<none>\s+-\s+-\s+(\d+)\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+			entries = append(entries, BabelEntry{
+				Protocol: protocol,
+				Prefix:   m[1],
+				Routes:   atoi(m[2]),
+				Sources:  atoi(m[3]),
+			})
+		}
+	}
+
+	return entries
+}
+
+func ParseBabelRoutes(data string) []BabelRoute {
+	routes := []BabelRoute{}
+	var protocol string
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if strings.HasPrefix(line, "BIRD") ||
+			strings.HasPrefix(line, "Access restricted") ||
+			strings.HasPrefix(line, "Prefix") {
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(\S+):$`).FindStringSubmatch(line); m != nil {
+			protocol = m[1]
+			continue
+		}
+
+		m := regexp.MustCompile(`^(\S+/\d+)\s+([0-9a-fA-F.:]+)\s+(\S+)\s+(\d+)\s+([*-])\s+(\d+)\s+([\d.]+)$`).FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		route := BabelRoute{
+			Protocol:  protocol,
+			Prefix:    m[1],
+			NextHop:   m[2],
+			Interface: m[3],
+			Metric:    atoi(m[4]),
+			Feasible:  m[5] == "*",
+			Seqno:     atoi(m[6]),
+		}
+		route.Expires, _ = strconv.ParseFloat(m[7], 64)
+
+		routes = append(routes, route)
+	}
+
+	return routes
+}
@@ -0,0 +1,45 @@
+package birdparse
+
+type BabelInterface struct {
+	Protocol    string  `json:"protocol"`
+	Interface   string  `json:"interface"`
+	State       string  `json:"state"`
+	Auth        string  `json:"auth"`
+	RxCost      int     `json:"rx_cost"`
+	Neighbors   int     `json:"neighbors"`
+	Timer       float64 `json:"timer"`
+	NextHopIPv4 string  `json:"next_hop_ipv4"`
+	NextHopIPv6 string  `json:"next_hop_ipv6"`
+}
+
+type BabelNeighbor struct {
+	Protocol  string  `json:"protocol"`
+	Address   string  `json:"address"`
+	Interface string  `json:"interface"`
+	Metric    int     `json:"metric"`
+	Routes    int     `json:"routes"`
+	Hellos    int     `json:"hellos"`
+	Expires   float64 `json:"expires"`
+	Auth      string  `json:"auth"`
+}
+
+type BabelEntry struct {
+	Protocol string `json:"protocol"`
+	Prefix   string `json:"prefix"`
+	RouterID string `json:"router_id"`
+	Metric   int    `json:"metric"`
+	Seqno    int    `json:"seqno"`
+	Routes   int    `json:"routes"`
+	Sources  int    `json:"sources"`
+}
+
+type BabelRoute struct {
+	Protocol  string  `json:"protocol"`
+	Prefix    string  `json:"prefix"`
+	NextHop   string  `json:"next_hop"`
+	Interface string  `json:"interface"`
+	Metric    int     `json:"metric"`
+	Feasible  bool    `json:"feasible"`
+	Seqno     int     `json:"seqno"`
+	Expires   float64 `json:"expires"`
+}
@@ -0,0 +1,137 @@
+package birdparse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBabelInterfaces(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+babel1:
+Interface  State  Auth  RX cost   Nbrs   Timer Next hop (v4)   Next hop (v6)
+wg0        Up     No         96      1   3.412 10.66.0.1       fe80::1
+wg1        Down   Yes        96      0   0.000 ::              ::`
+
+	expected := []BabelInterface{
+		{
+			Protocol:    "babel1",
+			Interface:   "wg0",
+			State:       "Up",
+			Auth:        "No",
+			RxCost:      96,
+			Neighbors:   1,
+			Timer:       3.412,
+			NextHopIPv4: "10.66.0.1",
+			NextHopIPv6: "fe80::1",
+		},
+		{
+			Protocol:    "babel1",
+			Interface:   "wg1",
+			State:       "Down",
+			Auth:        "Yes",
+			RxCost:      96,
+			NextHopIPv4: "::",
+			NextHopIPv6: "::",
+		},
+	}
+
+	result := ParseBabelInterfaces(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseBabelInterfaces() = %+v, want %+v", result, expected)
+	}
+}
+
+func TestParseBabelNeighbors(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+babel1:
+IP address                Interface  Metric Routes Hellos Expires Auth
+fe80::2                   wg0            96     12     16   4.807 No`
+
+	expected := []BabelNeighbor{
+		{
+			Protocol:  "babel1",
+			Address:   "fe80::2",
+			Interface: "wg0",
+			Metric:    96,
+			Routes:    12,
+			Hellos:    16,
+			Expires:   4.807,
+			Auth:      "No",
+		},
+	}
+
+	result := ParseBabelNeighbors(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseBabelNeighbors() = %+v, want %+v", result, expected)
+	}
+}
+
+func TestParseBabelEntries(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+babel1:
+Prefix                        Router ID               Metric Seqno  Routes Sources
+10.66.2.0/24                  0:0:0:2                    96    14       1       1
+10.66.9.0/24                  <none>                       -     -       1       2`
+
+	expected := []BabelEntry{
+		{
+			Protocol: "babel1",
+			Prefix:   "10.66.2.0/24",
+			RouterID: "0:0:0:2",
+			Metric:   96,
+			Seqno:    14,
+			Routes:   1,
+			Sources:  1,
+		},
+		{
+			Protocol: "babel1",
+			Prefix:   "10.66.9.0/24",
+			Routes:   1,
+			Sources:  2,
+		},
+	}
+
+	result := ParseBabelEntries(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseBabelEntries() = %+v, want %+v", result, expected)
+	}
+}
+
+func TestParseBabelRoutes(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+babel1:
+Prefix                        Nexthop                   Interface Metric     F Seqno Expires
+10.66.2.0/24                  fe80::2                   wg0           96 *    14  55.102
+10.66.3.0/24                  fe80::2                   wg0          192 -     3  12.000`
+
+	expected := []BabelRoute{
+		{
+			Protocol:  "babel1",
+			Prefix:    "10.66.2.0/24",
+			NextHop:   "fe80::2",
+			Interface: "wg0",
+			Metric:    96,
+			Feasible:  true,
+			Seqno:     14,
+			Expires:   55.102,
+		},
+		{
+			Protocol:  "babel1",
+			Prefix:    "10.66.3.0/24",
+			NextHop:   "fe80::2",
+			Interface: "wg0",
+			Metric:    192,
+			Seqno:     3,
+			Expires:   12,
+		},
+	}
+
+	result := ParseBabelRoutes(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseBabelRoutes() = %+v, want %+v", result, expected)
+	}
+}
@@ -0,0 +1,52 @@
+package birdparse
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+func ParseBFDSessions(data string) []BFDSession {
+	sessions := []BFDSession{}
+	var protocol string
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if strings.HasPrefix(line, "BIRD") ||
+			strings.HasPrefix(line, "Access restricted") ||
+			strings.HasPrefix(line, "IP address") {
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(\S+):$`).FindStringSubmatch(line); m != nil {
+			protocol = m[1]
+			continue
+		}
+
+		m := regexp.MustCompile(`^([0-9a-fA-F.:]+)\s+(\S+)\s+(\w+)\s+([0-9]{4}-[0-9]{2}-[0-9]{2}(?:\s+[0-9:]+)?|[0-9]{1,2}:[0-9]{1,2}:[0-9]{1,2}(?:\.[0-9]+)?|\S+)\s+([\d.]+)\s+([\d.]+)$`).FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+
+		session := BFDSession{
+			Protocol:  protocol,
+			Address:   m[1],
+			Interface: m[2],
+			State:     m[3],
+			Since:     m[4],
+		}
+		session.Interval, _ = strconv.ParseFloat(m[5], 64)
+		session.Timeout, _ = strconv.ParseFloat(m[6], 64)
+
+		if session.Interface == "---" {
+			session.Interface = ""
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions
+}
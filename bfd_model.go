@@ -0,0 +1,45 @@
+package birdparse
+
+import "net/netip"
+
+type BFDSession struct {
+	Protocol  string  `json:"protocol"`
+	Address   string  `json:"address"`
+	Interface string  `json:"interface"`
+	State     string  `json:"state"`
+	Since     string  `json:"since"`
+	Interval  float64 `json:"interval"`
+	Timeout   float64 `json:"timeout"`
+}
+
+func (s BFDSession) IsUp() bool {
+	return s.State == "Up"
+}
+
+func (p BgpProtocol) BFDSession(sessions []BFDSession) *BFDSession {
+	neighbor := p.NeighborAddr
+	if !neighbor.IsValid() {
+		var err error
+		if neighbor, err = netip.ParseAddr(p.NeighborAddress); err != nil {
+			return nil
+		}
+	}
+
+	zone := neighbor.Zone()
+	neighbor = neighbor.Unmap().WithZone("")
+
+	for i := range sessions {
+		addr, err := netip.ParseAddr(sessions[i].Address)
+		if err != nil || addr.Unmap().WithZone("") != neighbor {
+			continue
+		}
+
+		if zone != "" && sessions[i].Interface != "" && sessions[i].Interface != zone {
+			continue
+		}
+
+		return &sessions[i]
+	}
+
+	return nil
+}
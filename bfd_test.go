@@ -0,0 +1,64 @@
+package birdparse
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func TestParseBFDSessions(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+Access restricted
+bfd1:
+IP address                Interface  State      Since         Interval  Timeout
+2602:f92a:1315::e         eth0       Up         23:41:27.768     0.100    0.500
+10.151.104.9              ---        Down       2026-01-16       1.000    0.000`
+
+	expected := []BFDSession{
+		{
+			Protocol:  "bfd1",
+			Address:   "2602:f92a:1315::e",
+			Interface: "eth0",
+			State:     "Up",
+			Since:     "23:41:27.768",
+			Interval:  0.1,
+			Timeout:   0.5,
+		},
+		{
+			Protocol: "bfd1",
+			Address:  "10.151.104.9",
+			State:    "Down",
+			Since:    "2026-01-16",
+			Interval: 1,
+		},
+	}
+
+	result := ParseBFDSessions(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("ParseBFDSessions() = %+v, want %+v", result, expected)
+	}
+
+	protocol := BgpProtocol{Protocol: "AS213605_13_V6", NeighborAddress: "2602:f92a:1315::e"}
+	if session := protocol.BFDSession(result); session == nil || !session.IsUp() {
+		t.Errorf("BFDSession() = %+v, want session in state Up", session)
+	}
+
+	protocol.NeighborAddress = "2602:f92a:1315::11"
+	if session := protocol.BFDSession(result); session != nil {
+		t.Errorf("BFDSession() = %+v, want nil", session)
+	}
+
+	equivalent := []string{"2602:F92A:1315:0:0:0:0:E", "2602:f92a:1315:0000::000e", "2602:f92a:1315::e%eth0"}
+	for _, address := range equivalent {
+		protocol := BgpProtocol{NeighborAddress: address, NeighborAddr: netip.MustParseAddr(address)}
+		if session := protocol.BFDSession(result); session == nil || session.Address != "2602:f92a:1315::e" {
+			t.Errorf("BFDSession() for %s = %+v, want session for 2602:f92a:1315::e", address, session)
+		}
+	}
+
+	protocol = BgpProtocol{NeighborAddress: "2602:f92a:1315::e%eth1"}
+	if session := protocol.BFDSession(result); session != nil {
+		t.Errorf("BFDSession() for other interface = %+v, want nil", session)
+	}
+}
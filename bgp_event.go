@@ -0,0 +1,202 @@
+package birdparse
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type BgpEventDetector struct {
+	mu       sync.Mutex
+	sink     BgpEventSink
+	previous []BgpProtocol
+	polled   time.Time
+	seeded   bool
+	now      func() time.Time
+}
+
+func NewBgpEventDetector(sink BgpEventSink) *BgpEventDetector {
+	return &BgpEventDetector{sink: sink, now: time.Now}
+}
+
+func (d *BgpEventDetector) Update(protocols []BgpProtocol) []BgpEvent {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var events []BgpEvent
+	if d.seeded {
+		events = DetectBgpEvents(d.previous, protocols, d.polled)
+	}
+
+	d.previous = protocols
+	d.polled = d.now()
+	d.seeded = true
+
+	if d.sink != nil {
+		for _, event := range events {
+			d.sink.HandleBgpEvent(event)
+		}
+	}
+
+	return events
+}
+
+func DetectBgpEvents(previous, current []BgpProtocol, polled time.Time) []BgpEvent {
+	var events []BgpEvent
+
+	old := make(map[string]BgpProtocol, len(previous))
+	for _, p := range previous {
+		old[p.Protocol] = p
+	}
+
+	seen := make(map[string]bool, len(current))
+	for _, p := range current {
+		seen[p.Protocol] = true
+
+		if prev, ok := old[p.Protocol]; ok {
+			events = append(events, diffBgpProtocol(prev, p, polled)...)
+		}
+	}
+
+	for _, p := range previous {
+		if seen[p.Protocol] || p.BgpState != "Established" {
+			continue
+		}
+
+		events = append(events, newBgpEvent(BgpEventSessionDown, p, "bgp_state", p.BgpState, ""))
+	}
+
+	return events
+}
+
+func diffBgpProtocol(prev, cur BgpProtocol, polled time.Time) []BgpEvent {
+	var events []BgpEvent
+
+	wasUp := prev.BgpState == "Established"
+	isUp := cur.BgpState == "Established"
+
+	if prev.BgpState != cur.BgpState {
+		events = append(events, newBgpEvent(BgpEventStateChange, cur, "bgp_state", prev.BgpState, cur.BgpState))
+	}
+
+	switch {
+	case wasUp && !isUp:
+		events = append(events, newBgpEvent(BgpEventSessionDown, cur, "bgp_state", prev.BgpState, cur.BgpState))
+	case !wasUp && isUp:
+		events = append(events, newBgpEvent(BgpEventSessionUp, cur, "bgp_state", prev.BgpState, cur.BgpState))
+	case wasUp && isUp && sinceRestarted(prev.Since, cur.Since, polled):
+		events = append(events, newBgpEvent(BgpEventFlap, cur, "since", prev.Since, cur.Since))
+	}
+
+	if wasUp && isUp && prev.HoldTimer != 0 && cur.HoldTimer != 0 && prev.HoldTimer != cur.HoldTimer {
+		events = append(events, newBgpEvent(BgpEventHoldTimerReset, cur, "hold_timer", strconv.Itoa(prev.HoldTimer), strconv.Itoa(cur.HoldTimer)))
+	}
+
+	if !importLimitReached(prev) && importLimitReached(cur) {
+		events = append(events, newBgpEvent(BgpEventImportLimitHit, cur, "import_limit", cur.ImportLimit, cur.Routes.Imported))
+	}
+
+	events = append(events, detectCounterResets(prev, cur)...)
+
+	if prev.NeighborID != "" && cur.NeighborID != "" && prev.NeighborID != cur.NeighborID {
+		events = append(events, newBgpEvent(BgpEventNeighborIDChange, cur, "neighbor_id", prev.NeighborID, cur.NeighborID))
+	}
+
+	return events
+}
+
+func sinceRestarted(prev, cur string, polled time.Time) bool {
+	if prev == "" || cur == "" || prev == cur {
+		return false
+	}
+
+	if strings.Contains(prev, "-") || !strings.Contains(cur, "-") {
+		return true
+	}
+
+	// BIRD prints the time of day for recent changes and switches to the
+	// date of the change once the session is old enough. The date is the
+	// day of the previous poll, or the day before if that clock was earlier.
+	clock, err := time.Parse("15:04:05", strings.SplitN(prev, ".", 2)[0])
+	if err != nil || polled.IsZero() {
+		return false
+	}
+
+	day := polled
+	if clock.Hour()*3600+clock.Minute()*60+clock.Second() > polled.Hour()*3600+polled.Minute()*60+polled.Second() {
+		day = day.AddDate(0, 0, -1)
+	}
+
+	return !strings.HasPrefix(cur, day.Format("2006-01-02"))
+}
+
+func importLimitReached(p BgpProtocol) bool {
+	if p.ImportLimit == "" || p.Routes == nil {
+		return false
+	}
+
+	return atoi(p.Routes.Imported) >= atoi(p.ImportLimit)
+}
+
+func detectCounterResets(prev, cur BgpProtocol) []BgpEvent {
+	if prev.RouteChanges == nil || cur.RouteChanges == nil {
+		return nil
+	}
+
+	var events []BgpEvent
+
+	details := []struct {
+		name string
+		old  *BgpProtocolRouteChangeDetail
+		new  *BgpProtocolRouteChangeDetail
+	}{
+		{"import_updates", prev.RouteChanges.ImportUpdates, cur.RouteChanges.ImportUpdates},
+		{"import_withdraws", prev.RouteChanges.ImportWithdraws, cur.RouteChanges.ImportWithdraws},
+		{"export_updates", prev.RouteChanges.ExportUpdates, cur.RouteChanges.ExportUpdates},
+		{"export_withdraws", prev.RouteChanges.ExportWithdraws, cur.RouteChanges.ExportWithdraws},
+	}
+
+	for _, detail := range details {
+		if detail.old == nil || detail.new == nil {
+			continue
+		}
+
+		counters := []struct {
+			name string
+			old  string
+			new  string
+		}{
+			{"received", detail.old.Received, detail.new.Received},
+			{"rejected", detail.old.Rejected, detail.new.Rejected},
+			{"filtered", detail.old.Filtered, detail.new.Filtered},
+			{"ignored", detail.old.Ignored, detail.new.Ignored},
+			{"accepted", detail.old.Accepted, detail.new.Accepted},
+		}
+
+		for _, counter := range counters {
+			if counter.old == "" || counter.new == "" {
+				continue
+			}
+
+			if atoi(counter.new) < atoi(counter.old) {
+				events = append(events, newBgpEvent(BgpEventCounterReset, cur, detail.name+"."+counter.name, counter.old, counter.new))
+				break
+			}
+		}
+	}
+
+	return events
+}
+
+func newBgpEvent(eventType BgpEventType, p BgpProtocol, field, old, new string) BgpEvent {
+	return BgpEvent{
+		Type:            eventType,
+		Protocol:        p.Protocol,
+		NeighborAddress: p.NeighborAddress,
+		NeighborAS:      p.NeighborAS,
+		Field:           field,
+		Old:             old,
+		New:             new,
+	}
+}
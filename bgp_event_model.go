@@ -0,0 +1,34 @@
+package birdparse
+
+type BgpEventType string
+
+const (
+	BgpEventSessionUp        BgpEventType = "session_up"
+	BgpEventSessionDown      BgpEventType = "session_down"
+	BgpEventStateChange      BgpEventType = "state_change"
+	BgpEventFlap             BgpEventType = "flap"
+	BgpEventHoldTimerReset   BgpEventType = "hold_timer_reset"
+	BgpEventImportLimitHit   BgpEventType = "import_limit_hit"
+	BgpEventCounterReset     BgpEventType = "counter_reset"
+	BgpEventNeighborIDChange BgpEventType = "neighbor_id_change"
+)
+
+type BgpEvent struct {
+	Type            BgpEventType `json:"type"`
+	Protocol        string       `json:"protocol"`
+	NeighborAddress string       `json:"neighbor_address"`
+	NeighborAS      int          `json:"neighbor_as"`
+	Field           string       `json:"field"`
+	Old             string       `json:"old"`
+	New             string       `json:"new"`
+}
+
+type BgpEventSink interface {
+	HandleBgpEvent(event BgpEvent)
+}
+
+type BgpEventSinkFunc func(event BgpEvent)
+
+func (f BgpEventSinkFunc) HandleBgpEvent(event BgpEvent) {
+	f(event)
+}
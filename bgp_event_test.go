@@ -0,0 +1,143 @@
+package birdparse
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDetectBgpEvents(t *testing.T) {
+	previous := []BgpProtocol{
+		{
+			Protocol:        "peer_a",
+			BgpState:        "Established",
+			Since:           "2026-01-16",
+			NeighborAddress: "192.0.2.1",
+			NeighborAS:      64500,
+			NeighborID:      "192.0.2.1",
+		},
+		{
+			Protocol:        "peer_b",
+			BgpState:        "Active",
+			NeighborAddress: "192.0.2.2",
+			NeighborAS:      64501,
+		},
+		{
+			Protocol:        "peer_c",
+			BgpState:        "Established",
+			Since:           "2026-01-16",
+			NeighborAddress: "192.0.2.3",
+			NeighborAS:      64502,
+			HoldTimer:       240,
+			ImportLimit:     "100",
+			Routes:          &BgpProtocolBgpRoutes{Imported: "90"},
+			RouteChanges: &BgpProtocolRouteChanges{
+				ImportUpdates: &BgpProtocolRouteChangeDetail{Received: "500", Accepted: "400"},
+			},
+		},
+		{
+			Protocol:        "peer_d",
+			BgpState:        "Established",
+			Since:           "2026-01-16",
+			NeighborAddress: "192.0.2.4",
+			NeighborAS:      64503,
+		},
+	}
+
+	current := []BgpProtocol{
+		{
+			Protocol:        "peer_a",
+			BgpState:        "Idle",
+			NeighborAddress: "192.0.2.1",
+			NeighborAS:      64500,
+			NeighborID:      "192.0.2.1",
+		},
+		{
+			Protocol:        "peer_b",
+			BgpState:        "Established",
+			Since:           "12:00:00.000",
+			NeighborAddress: "192.0.2.2",
+			NeighborAS:      64501,
+		},
+		{
+			Protocol:        "peer_c",
+			BgpState:        "Established",
+			Since:           "12:00:00.000",
+			NeighborAddress: "192.0.2.3",
+			NeighborAS:      64502,
+			NeighborID:      "192.0.2.33",
+			HoldTimer:       90,
+			ImportLimit:     "100",
+			Routes:          &BgpProtocolBgpRoutes{Imported: "100"},
+			RouteChanges: &BgpProtocolRouteChanges{
+				ImportUpdates: &BgpProtocolRouteChangeDetail{Received: "12", Accepted: "10"},
+			},
+		},
+	}
+
+	expected := []BgpEvent{
+		{Type: BgpEventStateChange, Protocol: "peer_a", NeighborAddress: "192.0.2.1", NeighborAS: 64500, Field: "bgp_state", Old: "Established", New: "Idle"},
+		{Type: BgpEventSessionDown, Protocol: "peer_a", NeighborAddress: "192.0.2.1", NeighborAS: 64500, Field: "bgp_state", Old: "Established", New: "Idle"},
+		{Type: BgpEventStateChange, Protocol: "peer_b", NeighborAddress: "192.0.2.2", NeighborAS: 64501, Field: "bgp_state", Old: "Active", New: "Established"},
+		{Type: BgpEventSessionUp, Protocol: "peer_b", NeighborAddress: "192.0.2.2", NeighborAS: 64501, Field: "bgp_state", Old: "Active", New: "Established"},
+		{Type: BgpEventFlap, Protocol: "peer_c", NeighborAddress: "192.0.2.3", NeighborAS: 64502, Field: "since", Old: "2026-01-16", New: "12:00:00.000"},
+		{Type: BgpEventHoldTimerReset, Protocol: "peer_c", NeighborAddress: "192.0.2.3", NeighborAS: 64502, Field: "hold_timer", Old: "240", New: "90"},
+		{Type: BgpEventImportLimitHit, Protocol: "peer_c", NeighborAddress: "192.0.2.3", NeighborAS: 64502, Field: "import_limit", Old: "100", New: "100"},
+		{Type: BgpEventCounterReset, Protocol: "peer_c", NeighborAddress: "192.0.2.3", NeighborAS: 64502, Field: "import_updates.received", Old: "500", New: "12"},
+		{Type: BgpEventSessionDown, Protocol: "peer_d", NeighborAddress: "192.0.2.4", NeighborAS: 64503, Field: "bgp_state", Old: "Established", New: ""},
+	}
+
+	result := DetectBgpEvents(previous, current, time.Date(2026, 1, 17, 12, 0, 0, 0, time.UTC))
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("DetectBgpEvents() = %v, want %v", result, expected)
+	}
+}
+
+func TestBgpEventDetector(t *testing.T) {
+	var received []BgpEvent
+	detector := NewBgpEventDetector(BgpEventSinkFunc(func(event BgpEvent) {
+		received = append(received, event)
+	}))
+
+	down := []BgpProtocol{{Protocol: "peer_a", BgpState: "Connect"}}
+	up := []BgpProtocol{{Protocol: "peer_a", BgpState: "Established"}}
+
+	if events := detector.Update(down); len(events) != 0 {
+		t.Errorf("first Update() = %v, want no events", events)
+	}
+
+	detector.Update(up)
+
+	if len(received) != 2 || received[1].Type != BgpEventSessionUp {
+		t.Errorf("sink received %v, want state change and session up", received)
+	}
+}
+
+func TestDetectBgpEventsSinceRollover(t *testing.T) {
+	tests := []struct {
+		old, new string
+		polled   time.Time
+		flap     bool
+	}{
+		{"23:41:27.768", "2026-01-16", time.Date(2026, 1, 17, 10, 0, 0, 0, time.UTC), false},
+		{"08:00:00.000", "2026-01-17", time.Date(2026, 1, 17, 10, 0, 0, 0, time.UTC), false},
+		{"23:41:27.768", "2026-01-17", time.Date(2026, 1, 17, 10, 0, 0, 0, time.UTC), true},
+		{"08:00:00.000", "2026-01-18", time.Date(2026, 1, 17, 10, 0, 0, 0, time.UTC), true},
+		{"23:41:27.768", "2026-01-16", time.Time{}, false},
+		{"23:41:27.768", "00:05:12.004", time.Date(2026, 1, 17, 10, 0, 0, 0, time.UTC), true},
+		{"2026-01-16", "2026-01-17", time.Date(2026, 1, 17, 10, 0, 0, 0, time.UTC), true},
+		{"2026-01-16", "08:15:00.000", time.Date(2026, 1, 17, 10, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		previous := []BgpProtocol{{Protocol: "peer_a", BgpState: "Established", Since: tt.old}}
+		current := []BgpProtocol{{Protocol: "peer_a", BgpState: "Established", Since: tt.new}}
+
+		events := DetectBgpEvents(previous, current, tt.polled)
+
+		if flap := len(events) == 1 && events[0].Type == BgpEventFlap; flap != tt.flap || len(events) > 1 {
+			t.Errorf("DetectBgpEvents(%q -> %q at %v) = %v, want flap %v", tt.old, tt.new, tt.polled, events, tt.flap)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package birdparse
 
 import (
+	"fmt"
+	"net/netip"
 	"regexp"
 	"strconv"
 	"strings"
@@ -9,6 +11,7 @@ import (
 func ParseBGPProtocol(data string) BgpProtocol {
 	result := BgpProtocol{}
 	seenChannels := make(map[string]bool)
+	var capabilities string
 
 	lines := strings.Split(data, "\n")
 
@@ -22,12 +25,11 @@ func ParseBGPProtocol(data string) BgpProtocol {
 			seenChannels["ipv6"] = true
 		}
 
-		headerRE := regexp.MustCompile(`^(\S+)\s+BGP\s+([-\w]+|\.{3,}|-+)\s+(\w+)\s+([0-9]{4}-[0-9]{2}-[0-9]{2}|[0-9]{1,2}:[0-9]{1,2}:[0-9]{1,2}(?:\.[0-9]{1,3})?)\s*(.*)$`)
-
-		if m := headerRE.FindStringSubmatch(line); m != nil {
+		if m := matchProtocolHeader(line, "BGP"); m != nil {
 			result.Protocol = m[1]
 			result.Table = m[2]
 			result.State = m[3]
+			result.Since = m[4]
 
 			result.Connection = strings.TrimSpace(m[5])
 			continue
@@ -68,20 +70,8 @@ func ParseBGPProtocol(data string) BgpProtocol {
 			continue
 		}
 
-		if m := regexp.MustCompile(`^\s+Routes:\s+(\d+)\s+imported,\s+(\d+)\s+exported`).FindStringSubmatch(line); m != nil {
-			result.Routes = &BgpProtocolBgpRoutes{
-				Imported: m[1],
-				Exported: m[2],
-			}
-			continue
-		}
-		if m := regexp.MustCompile(`^\s+Routes:\s+(\d+)\s+imported,\s+(\d+)\s+filtered,\s+(\d+)\s+exported,\s+(\d+)\s+preferred`).FindStringSubmatch(line); m != nil {
-			result.Routes = &BgpProtocolBgpRoutes{
-				Imported:  m[1],
-				Filtered:  m[2],
-				Exported:  m[3],
-				Preferred: m[4],
-			}
+		if routes := parseChannelRoutes(line); routes != nil {
+			result.Routes = routes
 			continue
 		}
 
@@ -105,6 +95,21 @@ func ParseBGPProtocol(data string) BgpProtocol {
 			continue
 		}
 
+		if m := regexp.MustCompile(`^\s+(Local|Neighbor) capabilities$`).FindStringSubmatch(line); m != nil {
+			capabilities = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Role:\s+(\w+)$`).FindStringSubmatch(line); m != nil {
+			switch capabilities {
+			case "Local":
+				result.LocalRole = m[1]
+			case "Neighbor":
+				result.NeighborRole = m[1]
+			}
+			continue
+		}
+
 		if m := regexp.MustCompile(`^\s+Session:\s+(.*)$`).FindStringSubmatch(line); m != nil {
 			result.BgpSession = strings.Fields(m[1])
 			continue
@@ -132,59 +137,8 @@ func ParseBGPProtocol(data string) BgpProtocol {
 			continue
 		}
 
-		if m := regexp.MustCompile(`^\s+Import updates:\s+(\d+)\s+(\d+)\s+(\d+)\s+(\d+)\s+(\d+)$`).FindStringSubmatch(line); m != nil {
-			if result.RouteChanges == nil {
-				result.RouteChanges = &BgpProtocolRouteChanges{}
-			}
-			result.RouteChanges.ImportUpdates = &BgpProtocolRouteChangeDetail{
-				Received: parseOptionalIntAsString(m[1]),
-				Rejected: parseOptionalIntAsString(m[2]),
-				Filtered: parseOptionalIntAsString(m[3]),
-				Ignored:  parseOptionalIntAsString(m[4]),
-				Accepted: parseOptionalIntAsString(m[5]),
-			}
-			continue
-		}
-
-		if m := regexp.MustCompile(`^\s+Import withdraws:\s+(\d+)\s+(\d+|--+)\s+(\d+|--+)\s+(\d+|--+)\s+(\d+|--+)$`).FindStringSubmatch(line); m != nil {
-			if result.RouteChanges == nil {
-				result.RouteChanges = &BgpProtocolRouteChanges{}
-			}
-			result.RouteChanges.ImportWithdraws = &BgpProtocolRouteChangeDetail{
-				Received: parseOptionalIntAsString(m[1]),
-				Rejected: parseOptionalIntAsString(m[2]),
-				Filtered: parseOptionalIntAsString(m[3]),
-				Ignored:  parseOptionalIntAsString(m[4]),
-				Accepted: parseOptionalIntAsString(m[5]),
-			}
-			continue
-		}
-
-		if m := regexp.MustCompile(`^\s+Export updates:\s+(\d+)\s+(\d+|--+)\s+(\d+|--+)\s+(\d+|--+)\s+(\d+|--+)$`).FindStringSubmatch(line); m != nil {
-			if result.RouteChanges == nil {
-				result.RouteChanges = &BgpProtocolRouteChanges{}
-			}
-			result.RouteChanges.ExportUpdates = &BgpProtocolRouteChangeDetail{
-				Received: parseOptionalIntAsString(m[1]),
-				Rejected: parseOptionalIntAsString(m[2]),
-				Filtered: parseOptionalIntAsString(m[3]),
-				Ignored:  parseOptionalIntAsString(m[4]),
-				Accepted: parseOptionalIntAsString(m[5]),
-			}
-			continue
-		}
-
-		if m := regexp.MustCompile(`^\s+Export withdraws:\s+(\d+)\s+(\d+|--+)\s+(\d+|--+)\s+(\d+|--+)\s+(\d+|--+)$`).FindStringSubmatch(line); m != nil {
-			if result.RouteChanges == nil {
-				result.RouteChanges = &BgpProtocolRouteChanges{}
-			}
-			result.RouteChanges.ExportWithdraws = &BgpProtocolRouteChangeDetail{
-				Received: parseOptionalIntAsString(m[1]),
-				Rejected: parseOptionalIntAsString(m[2]),
-				Filtered: parseOptionalIntAsString(m[3]),
-				Ignored:  parseOptionalIntAsString(m[4]),
-				Accepted: parseOptionalIntAsString(m[5]),
-			}
+		if changes, ok := applyRouteChangeLine(result.RouteChanges, line); ok {
+			result.RouteChanges = changes
 			continue
 		}
 	}
@@ -198,46 +152,40 @@ func ParseBGPProtocol(data string) BgpProtocol {
 		result = BgpProtocol{}
 	}
 
+	result.parseAddrs()
+
 	return result
 }
 
-func ParseBGPProtocols(data string) []BgpProtocol {
-	var (
-		results      []BgpProtocol
-		currentBlock []string
-		inBlock      bool
-	)
-
-	lines := strings.Split(data, "\n")
-
-	for _, raw := range lines {
-		line := strings.TrimRight(raw, "\r")
-
-		if strings.HasPrefix(line, "BIRD") ||
-			strings.HasPrefix(line, "Access restricted") {
-			continue
-		}
-
-		if isBGPHeaderLine(line) {
-			if inBlock && len(currentBlock) > 0 {
-				p := ParseBGPProtocol(strings.Join(currentBlock, "\n"))
-				if p.IsValid() {
-					results = append(results, p)
-				}
-			}
+func (p *BgpProtocol) parseAddrs() {
+	fields := []struct {
+		name  string
+		value string
+		addr  *netip.Addr
+	}{
+		{"neighbor address", p.NeighborAddress, &p.NeighborAddr},
+		{"source address", p.SourceAddress, &p.SourceAddr},
+		{"neighbor id", p.NeighborID, &p.NeighborRouterID},
+	}
 
-			currentBlock = []string{line}
-			inBlock = true
+	for _, field := range fields {
+		if field.value == "" {
 			continue
 		}
 
-		if inBlock && strings.TrimSpace(line) != "" {
-			currentBlock = append(currentBlock, line)
+		addr, err := netip.ParseAddr(field.value)
+		if err != nil {
+			p.Errors = append(p.Errors, fmt.Errorf("%s %q: %w", field.name, field.value, err))
 		}
+		*field.addr = addr
 	}
+}
+
+func ParseBGPProtocols(data string) []BgpProtocol {
+	var results []BgpProtocol
 
-	if inBlock && len(currentBlock) > 0 {
-		p := ParseBGPProtocol(strings.Join(currentBlock, "\n"))
+	for _, block := range splitProtocolBlocks(data, "BGP") {
+		p := ParseBGPProtocol(block)
 		if p.IsValid() {
 			results = append(results, p)
 		}
@@ -245,8 +193,3 @@ func ParseBGPProtocols(data string) []BgpProtocol {
 
 	return results
 }
-
-func isBGPHeaderLine(line string) bool {
-	headerRE := regexp.MustCompile(`^(\S+)\s+BGP\s+([-\w]+|\.{3,}|-+)\s+(\w+)\s+([0-9]{4}-[0-9]{2}-[0-9]{2}|[0-9]{1,2}:[0-9]{1,2}:[0-9]{1,2}(?:\.[0-9]{1,3})?)\s*(.*)$`)
-	return headerRE.FindStringSubmatch(line) != nil
-}
@@ -1,5 +1,7 @@
 package birdparse
 
+import "net/netip"
+
 type BgpProtocolBgpRoutes struct {
 	Imported  string `json:"imported"`
 	Filtered  string `json:"filtered"`
@@ -26,6 +28,7 @@ type BgpProtocol struct {
 	Protocol         string                   `json:"protocol"`
 	Table            string                   `json:"table"`
 	State            string                   `json:"state"`
+	Since            string                   `json:"since"`
 	Connection       string                   `json:"connection"`
 	Description      string                   `json:"description"`
 	DescriptionShort string                   `json:"description_short"`
@@ -47,6 +50,13 @@ type BgpProtocol struct {
 	HoldTimerNow     int                      `json:"hold_timer_now"`
 	Keepalive        int                      `json:"keepalive"`
 	KeepaliveNow     int                      `json:"keepalive_now"`
+	LocalRole        string                   `json:"local_role"`
+	NeighborRole     string                   `json:"neighbor_role"`
+
+	NeighborAddr     netip.Addr `json:"-"`
+	SourceAddr       netip.Addr `json:"-"`
+	NeighborRouterID netip.Addr `json:"-"`
+	Errors           []error    `json:"-"`
 }
 
 func (p BgpProtocol) IsValid() bool {
@@ -57,3 +67,52 @@ func (p BgpProtocol) IsValid() bool {
 
 	return true
 }
+
+func (p BgpProtocol) PeerRole() string {
+	if p.NeighborRole != "" {
+		return p.NeighborRole
+	}
+
+	switch p.LocalRole {
+	case "provider":
+		return "customer"
+	case "customer":
+		return "provider"
+	case "rs_server":
+		return "rs_client"
+	case "rs_client":
+		return "rs_server"
+	case "peer":
+		return "peer"
+	}
+
+	return ""
+}
+
+func (p BgpProtocol) IsImportLeak(route Route) bool {
+	if route.BGP == nil || route.BGP.OTC == 0 {
+		return false
+	}
+
+	switch p.PeerRole() {
+	case "customer", "rs_client":
+		return true
+	case "peer":
+		return route.BGP.OTC != p.NeighborAS
+	}
+
+	return false
+}
+
+func (p BgpProtocol) IsExportLeak(route Route) bool {
+	if route.BGP == nil || route.BGP.OTC == 0 {
+		return false
+	}
+
+	switch p.PeerRole() {
+	case "provider", "rs_server", "peer":
+		return true
+	}
+
+	return false
+}
@@ -1,6 +1,7 @@
 package birdparse
 
 import (
+	"net/netip"
 	"reflect"
 	"testing"
 )
@@ -111,6 +112,7 @@ AS151673_16_V6 BGP        ---        start  2026-01-16    Passive
 			Protocol:     "AS213605_13_V6",
 			Table:        "master6",
 			State:        "up",
+			Since:        "23:41:27.768",
 			Connection:   "Established",
 			Preference:   100,
 			InputFilter:  "import_filter_test1",
@@ -153,16 +155,19 @@ AS151673_16_V6 BGP        ---        start  2026-01-16    Passive
 					Accepted: "157",
 				},
 			},
-			BgpState:        "Established",
-			NeighborAddress: "2602:f92a:1315::e",
-			NeighborAS:      213605,
-			NeighborID:      "23.151.104.19",
+			BgpState:         "Established",
+			NeighborAddress:  "2602:f92a:1315::e",
+			NeighborAddr:     netip.MustParseAddr("2602:f92a:1315::e"),
+			NeighborAS:       213605,
+			NeighborID:       "23.151.104.19",
+			NeighborRouterID: netip.MustParseAddr("23.151.104.19"),
 			BgpSession: []string{
 				"external",
 				"route-server",
 				"AS4",
 			},
 			SourceAddress: "2602:f92a:1315::1",
+			SourceAddr:    netip.MustParseAddr("2602:f92a:1315::1"),
 			RouteLimitAt:  "31",
 			HoldTimer:     240,
 			HoldTimerNow:  211,
@@ -173,12 +178,14 @@ AS151673_16_V6 BGP        ---        start  2026-01-16    Passive
 			Protocol:        "AS151673_16_V6",
 			Table:           "master6",
 			State:           "start",
+			Since:           "2026-01-16",
 			Connection:      "Passive",
 			Preference:      100,
 			InputFilter:     "(unnamed)",
 			OutputFilter:    "(unnamed)",
 			BgpState:        "Passive",
 			NeighborAddress: "2602:f92a:1315::11",
+			NeighborAddr:    netip.MustParseAddr("2602:f92a:1315::11"),
 			NeighborAS:      151673,
 		},
 	}
@@ -189,3 +196,67 @@ AS151673_16_V6 BGP        ---        start  2026-01-16    Passive
 		t.Errorf("ParseBGPProtocols() = %v, want %v", result, expected)
 	}
 }
+
+func TestParseBGPProtocolRoles(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+AS64500_V4 BGP        ---        up     2026-01-16    Established
+  BGP state:          Established
+    Neighbor address: 192.0.2.1
+    Neighbor AS:      64500
+    Local AS:         203168
+    Neighbor ID:      192.0.2.1
+    Local capabilities
+      Multiprotocol
+        AF announced: ipv4
+      Route refresh
+      4-octet AS numbers
+      Role: provider
+    Neighbor capabilities
+      Multiprotocol
+        AF announced: ipv4
+      Route refresh
+      4-octet AS numbers
+      Role: customer
+    Session:          external AS4
+    Source address:   192.0.2.2
+  Channel ipv4
+    State:          UP
+    Table:          master4`
+
+	result := ParseBGPProtocols(data)
+
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 protocol, got %d", len(result))
+	}
+
+	protocol := result[0]
+
+	if protocol.LocalRole != "provider" || protocol.NeighborRole != "customer" {
+		t.Errorf("roles = %q/%q, want %q/%q", protocol.LocalRole, protocol.NeighborRole, "provider", "customer")
+	}
+
+	leaked := Route{BGP: &RouteBGPInfo{OTC: 64501}}
+	clean := Route{BGP: &RouteBGPInfo{}}
+
+	if !protocol.IsImportLeak(leaked) {
+		t.Errorf("IsImportLeak() = false for OTC route from customer")
+	}
+
+	if protocol.IsImportLeak(clean) {
+		t.Errorf("IsImportLeak() = true for route without OTC")
+	}
+
+	peer := BgpProtocol{LocalRole: "peer", NeighborAS: 64501}
+
+	if peer.IsImportLeak(leaked) {
+		t.Errorf("IsImportLeak() = true for OTC matching peer AS")
+	}
+
+	if !peer.IsExportLeak(leaked) {
+		t.Errorf("IsExportLeak() = false for OTC route sent to peer")
+	}
+
+	if protocol.IsExportLeak(leaked) {
+		t.Errorf("IsExportLeak() = true for OTC route sent to customer")
+	}
+}
@@ -0,0 +1,177 @@
+package birdwatcher
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LaunchPad-Network/birdparse"
+)
+
+func NewEnvelope(cachedAt time.Time, ttl time.Duration, fromCache bool) Envelope {
+	cachedAt = cachedAt.UTC()
+
+	return Envelope{
+		API: API{
+			Version:         APIVersion,
+			ResultFromCache: fromCache,
+			CacheStatus: CacheStatus{
+				CachedAt: CachedAt{
+					Date:         cachedAt,
+					TimezoneType: "UTC",
+					Timezone:     "UTC",
+				},
+				OrigTTL: int(ttl.Seconds()),
+			},
+		},
+		CachedAt: cachedAt,
+		TTL:      cachedAt.Add(ttl),
+	}
+}
+
+func NewStatus(s birdparse.Status) Status {
+	message := ""
+	if s.State != "" {
+		message = "Daemon is " + s.State
+	}
+
+	return Status{
+		CurrentServer: s.ServerTime,
+		LastReboot:    s.LastReboot,
+		LastReconfig:  s.LastReconfiguration,
+		Message:       message,
+		RouterID:      s.RouterID,
+		Version:       s.Version,
+	}
+}
+
+func NewProtocol(p birdparse.BgpProtocol) Protocol {
+	result := Protocol{
+		BirdProtocol:    p.Protocol,
+		Table:           p.Table,
+		State:           p.State,
+		StateChanged:    p.Since,
+		Connection:      p.Connection,
+		Description:     p.Description,
+		Preference:      p.Preference,
+		InputFilter:     p.InputFilter,
+		OutputFilter:    p.OutputFilter,
+		ImportLimit:     atoi(p.ImportLimit),
+		LimitAction:     p.LimitAction,
+		BgpState:        p.BgpState,
+		NeighborAddress: p.NeighborAddress,
+		NeighborAS:      p.NeighborAS,
+		NeighborID:      p.NeighborID,
+		SourceAddress:   p.SourceAddress,
+	}
+
+	if p.HoldTimer != 0 {
+		result.HoldTimer = fmt.Sprintf("%d/%d", p.HoldTimerNow, p.HoldTimer)
+	}
+
+	if p.Keepalive != 0 {
+		result.Keepalive = fmt.Sprintf("%d/%d", p.KeepaliveNow, p.Keepalive)
+	}
+
+	if p.Routes != nil {
+		result.Routes = &ProtocolRoutes{
+			Imported:  atoi(p.Routes.Imported),
+			Filtered:  atoi(p.Routes.Filtered),
+			Exported:  atoi(p.Routes.Exported),
+			Preferred: atoi(p.Routes.Preferred),
+		}
+	}
+
+	if p.RouteChanges != nil {
+		result.RouteChanges = make(map[string]RouteChangeCounters)
+
+		details := map[string]*birdparse.BgpProtocolRouteChangeDetail{
+			"import_updates":   p.RouteChanges.ImportUpdates,
+			"import_withdraws": p.RouteChanges.ImportWithdraws,
+			"export_updates":   p.RouteChanges.ExportUpdates,
+			"export_withdraws": p.RouteChanges.ExportWithdraws,
+		}
+
+		for name, detail := range details {
+			if detail == nil {
+				continue
+			}
+
+			result.RouteChanges[name] = RouteChangeCounters{
+				Received: atoi(detail.Received),
+				Rejected: atoi(detail.Rejected),
+				Filtered: atoi(detail.Filtered),
+				Ignored:  atoi(detail.Ignored),
+				Accepted: atoi(detail.Accepted),
+			}
+		}
+	}
+
+	return result
+}
+
+func NewProtocols(protocols []birdparse.BgpProtocol) map[string]Protocol {
+	result := make(map[string]Protocol, len(protocols))
+	for _, p := range protocols {
+		result[p.Protocol] = NewProtocol(p)
+	}
+	return result
+}
+
+func NewRoute(r birdparse.Route) Route {
+	result := Route{
+		Network:      r.Network,
+		Gateway:      r.Gateway,
+		Interface:    r.Interface,
+		FromProtocol: r.FromProtocol,
+		LearntFrom:   r.FromAddress,
+		Type:         r.Type,
+		Primary:      r.Primary,
+		Metric:       r.Metric,
+		Age:          r.Since,
+	}
+
+	if result.Type == nil {
+		result.Type = []string{}
+	}
+
+	if r.BGP != nil {
+		asPath := make([]string, len(r.BGP.ASPath))
+		for i, asn := range r.BGP.ASPath {
+			asPath[i] = strconv.Itoa(asn)
+		}
+
+		result.BGP = &RouteBGP{
+			Origin:           r.BGP.Origin,
+			ASPath:           asPath,
+			NextHop:          strings.Join(r.BGP.NextHop, " "),
+			LocalPref:        strconv.Itoa(r.BGP.LocalPref),
+			MED:              strconv.Itoa(r.BGP.MED),
+			Communities:      nonNil(r.BGP.Communities),
+			LargeCommunities: nonNil(r.BGP.LargeCommunities),
+		}
+	}
+
+	return result
+}
+
+func NewRoutes(routes []birdparse.Route) []Route {
+	result := make([]Route, len(routes))
+	for i, r := range routes {
+		result[i] = NewRoute(r)
+	}
+	return result
+}
+
+func nonNil(values [][]int) [][]int {
+	if values == nil {
+		return [][]int{}
+	}
+	return values
+}
+
+func atoi(s string) int {
+	i, _ := strconv.Atoi(s)
+	return i
+}
@@ -0,0 +1,113 @@
+package birdwatcher
+
+import "time"
+
+const APIVersion = "2.2.0"
+
+type CachedAt struct {
+	Date         time.Time `json:"date"`
+	TimezoneType string    `json:"timezone_type"`
+	Timezone     string    `json:"timezone"`
+}
+
+type CacheStatus struct {
+	CachedAt CachedAt `json:"cached_at"`
+	OrigTTL  int      `json:"orig_ttl"`
+}
+
+type API struct {
+	Version         string      `json:"Version"`
+	ResultFromCache bool        `json:"result_from_cache"`
+	CacheStatus     CacheStatus `json:"cache_status"`
+}
+
+type Envelope struct {
+	API      API       `json:"api"`
+	CachedAt time.Time `json:"cached_at"`
+	TTL      time.Time `json:"ttl"`
+}
+
+type Status struct {
+	CurrentServer string `json:"current_server"`
+	LastReboot    string `json:"last_reboot"`
+	LastReconfig  string `json:"last_reconfig"`
+	Message       string `json:"message"`
+	RouterID      string `json:"router_id"`
+	Version       string `json:"version"`
+}
+
+type StatusResponse struct {
+	Envelope
+	Status Status `json:"status"`
+}
+
+type ProtocolRoutes struct {
+	Imported  int `json:"imported"`
+	Filtered  int `json:"filtered"`
+	Exported  int `json:"exported"`
+	Preferred int `json:"preferred"`
+}
+
+type RouteChangeCounters struct {
+	Received int `json:"received"`
+	Rejected int `json:"rejected"`
+	Filtered int `json:"filtered"`
+	Ignored  int `json:"ignored"`
+	Accepted int `json:"accepted"`
+}
+
+type Protocol struct {
+	BirdProtocol    string                         `json:"bird_protocol"`
+	Table           string                         `json:"table"`
+	State           string                         `json:"state"`
+	StateChanged    string                         `json:"state_changed"`
+	Connection      string                         `json:"connection"`
+	Description     string                         `json:"description"`
+	Preference      int                            `json:"preference"`
+	InputFilter     string                         `json:"input_filter"`
+	OutputFilter    string                         `json:"output_filter"`
+	ImportLimit     int                            `json:"import_limit,omitempty"`
+	LimitAction     string                         `json:"limit_action,omitempty"`
+	Routes          *ProtocolRoutes                `json:"routes,omitempty"`
+	RouteChanges    map[string]RouteChangeCounters `json:"route_changes,omitempty"`
+	BgpState        string                         `json:"bgp_state"`
+	NeighborAddress string                         `json:"neighbor_address"`
+	NeighborAS      int                            `json:"neighbor_as"`
+	NeighborID      string                         `json:"neighbor_id,omitempty"`
+	SourceAddress   string                         `json:"source_address,omitempty"`
+	HoldTimer       string                         `json:"hold_timer,omitempty"`
+	Keepalive       string                         `json:"keepalive,omitempty"`
+}
+
+type ProtocolsResponse struct {
+	Envelope
+	Protocols map[string]Protocol `json:"protocols"`
+}
+
+type RouteBGP struct {
+	Origin           string   `json:"origin"`
+	ASPath           []string `json:"as_path"`
+	NextHop          string   `json:"next_hop"`
+	LocalPref        string   `json:"local_pref"`
+	MED              string   `json:"med"`
+	Communities      [][]int  `json:"communities"`
+	LargeCommunities [][]int  `json:"large_communities"`
+}
+
+type Route struct {
+	Network      string    `json:"network"`
+	Gateway      string    `json:"gateway"`
+	Interface    string    `json:"interface"`
+	FromProtocol string    `json:"from_protocol"`
+	LearntFrom   string    `json:"learnt_from"`
+	Type         []string  `json:"type"`
+	Primary      bool      `json:"primary"`
+	Metric       int       `json:"metric"`
+	Age          string    `json:"age"`
+	BGP          *RouteBGP `json:"bgp,omitempty"`
+}
+
+type RoutesResponse struct {
+	Envelope
+	Routes []Route `json:"routes"`
+}
@@ -0,0 +1,189 @@
+package birdwatcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/LaunchPad-Network/birdparse"
+)
+
+var protocolIDRE = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
+
+type Server struct {
+	Network  string
+	Address  string
+	Timeout  time.Duration
+	CacheTTL time.Duration
+
+	once  sync.Once
+	mux   *http.ServeMux
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+	now   func() time.Time
+}
+
+type cacheEntry struct {
+	output string
+	at     time.Time
+}
+
+type httpError struct {
+	status  int
+	message string
+}
+
+func (e *httpError) Error() string {
+	return e.message
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.once.Do(s.init)
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) init() {
+	s.mux = http.NewServeMux()
+	s.cache = make(map[string]cacheEntry)
+	if s.now == nil {
+		s.now = time.Now
+	}
+
+	s.mux.HandleFunc("GET /status", s.status)
+	s.mux.HandleFunc("GET /protocols/bgp", s.protocols)
+	s.mux.HandleFunc("GET /routes/protocol/{id}", s.routes("show route all protocol "))
+	s.mux.HandleFunc("GET /routes/filtered/{id}", s.routes("show route all filtered protocol "))
+	s.mux.HandleFunc("GET /routes/noexport/{id}", s.routes("show route all noexport "))
+}
+
+func (s *Server) status(w http.ResponseWriter, r *http.Request) {
+	output, envelope, err := s.fetch(r.Context(), "show status")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, StatusResponse{
+		Envelope: envelope,
+		Status:   NewStatus(birdparse.ParseStatus(output)),
+	})
+}
+
+func (s *Server) protocols(w http.ResponseWriter, r *http.Request) {
+	output, envelope, err := s.fetch(r.Context(), "show protocols all")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ProtocolsResponse{
+		Envelope:  envelope,
+		Protocols: NewProtocols(birdparse.ParseBGPProtocols(output)),
+	})
+}
+
+func (s *Server) routes(command string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		if !protocolIDRE.MatchString(id) {
+			writeError(w, &httpError{http.StatusBadRequest, "invalid protocol id"})
+			return
+		}
+
+		output, envelope, err := s.fetch(r.Context(), command+id)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, RoutesResponse{
+			Envelope: envelope,
+			Routes:   NewRoutes(birdparse.ParseRoutes(output)),
+		})
+	}
+}
+
+func (s *Server) fetch(ctx context.Context, command string) (string, Envelope, error) {
+	now := s.now()
+
+	s.mu.Lock()
+	entry, ok := s.cache[command]
+	s.mu.Unlock()
+
+	if ok && now.Sub(entry.at) < s.CacheTTL {
+		return entry.output, NewEnvelope(entry.at, s.CacheTTL, true), nil
+	}
+
+	output, err := s.query(ctx, command)
+	if err != nil {
+		return "", Envelope{}, err
+	}
+
+	if s.CacheTTL > 0 {
+		s.mu.Lock()
+		for key, cached := range s.cache {
+			if now.Sub(cached.at) >= s.CacheTTL {
+				delete(s.cache, key)
+			}
+		}
+		s.cache[command] = cacheEntry{output: output, at: now}
+		s.mu.Unlock()
+	}
+
+	return output, NewEnvelope(now, s.CacheTTL, false), nil
+}
+
+func (s *Server) query(ctx context.Context, command string) (string, error) {
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	network := s.Network
+	if network == "" {
+		network = "unix"
+	}
+
+	client, err := birdparse.Dial(ctx, network, s.Address)
+	if err != nil {
+		return "", &httpError{http.StatusBadGateway, "bird unavailable"}
+	}
+	defer client.Close()
+
+	output, err := client.Query(ctx, command)
+
+	var birdErr *birdparse.BirdError
+	switch {
+	case errors.As(err, &birdErr) && birdErr.Code == 8003:
+		return "", &httpError{http.StatusNotFound, birdErr.Message}
+	case errors.As(err, &birdErr):
+		return "", &httpError{http.StatusBadRequest, birdErr.Message}
+	case err != nil:
+		return "", &httpError{http.StatusBadGateway, "bird query failed"}
+	}
+
+	return output, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var httpErr *httpError
+	if errors.As(err, &httpErr) {
+		status = httpErr.status
+	}
+
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,206 @@
+package birdwatcher
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/LaunchPad-Network/birdparse/internal/birdtest"
+)
+
+const testProtocols = `peer_a     BGP        ---        up     2026-01-16    Established
+  Description:    Transit A
+  BGP state:          Established
+    Neighbor address: 192.0.2.1
+    Neighbor AS:      64500
+    Neighbor ID:      192.0.2.1
+    Hold timer:       211.000/240
+    Keepalive timer:  32.000/80
+  Channel ipv4
+    State:          UP
+    Table:          master4
+    Preference:     100
+    Input filter:   ACCEPT
+    Output filter:  REJECT
+    Routes:         1 imported, 0 filtered, 0 exported, 1 preferred
+    Route change stats:     received   rejected   filtered    ignored   accepted
+      Import updates:              2          0          0          0          1
+      Import withdraws:            1          0        ---          0          1
+      Export updates:              0          0          0        ---          0
+      Export withdraws:            0        ---        ---        ---          0`
+
+const testRoutes = `Table master4:
+192.0.2.0/24         unicast [peer_a 2026-01-16 from 192.0.2.10] * (100) [AS64500i]
+        via 192.0.2.1 on eth0
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 64500 64501
+        BGP.next_hop: 192.0.2.1
+        BGP.local_pref: 100
+        BGP.community: (64500,1)`
+
+func newTestServer(t *testing.T) (*Server, *birdtest.Server) {
+	bird := birdtest.NewServer(t)
+	bird.Handle("show status", `BIRD 2.17.1
+Router ID is 192.0.2.254
+Current server time is 2026-01-16 12:00:00.000
+Last reboot on 2026-01-10 08:30:00.000
+Last reconfiguration on 2026-01-15 22:10:05.000
+Daemon is up and running`)
+	bird.Handle("show protocols all", testProtocols)
+	bird.Handle("show route all protocol peer_a", testRoutes)
+	bird.Handle("show route all filtered protocol peer_a", "")
+	bird.Handle("show route all noexport peer_a", testRoutes)
+
+	now := time.Date(2026, 1, 16, 12, 0, 0, 0, time.UTC)
+
+	return &Server{
+		Network:  bird.Network,
+		Address:  bird.Address,
+		Timeout:  time.Second,
+		CacheTTL: 5 * time.Minute,
+		now:      func() time.Time { return now },
+	}, bird
+}
+
+func getJSON(t *testing.T, handler http.Handler, path string) map[string]any {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", path, nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET %s = %d %s", path, recorder.Code, recorder.Body.String())
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+
+	return result
+}
+
+func TestServerStatus(t *testing.T) {
+	server, bird := newTestServer(t)
+
+	first := getJSON(t, server, "/status")
+
+	expectedStatus := map[string]any{
+		"current_server": "2026-01-16 12:00:00.000",
+		"last_reboot":    "2026-01-10 08:30:00.000",
+		"last_reconfig":  "2026-01-15 22:10:05.000",
+		"message":        "Daemon is up and running",
+		"router_id":      "192.0.2.254",
+		"version":        "2.17.1",
+	}
+	if !reflect.DeepEqual(first["status"], expectedStatus) {
+		t.Errorf("status = %v, want %v", first["status"], expectedStatus)
+	}
+
+	expectedAPI := map[string]any{
+		"Version":           APIVersion,
+		"result_from_cache": false,
+		"cache_status": map[string]any{
+			"cached_at": map[string]any{
+				"date":          "2026-01-16T12:00:00Z",
+				"timezone_type": "UTC",
+				"timezone":      "UTC",
+			},
+			"orig_ttl": float64(300),
+		},
+	}
+	if !reflect.DeepEqual(first["api"], expectedAPI) {
+		t.Errorf("api = %v, want %v", first["api"], expectedAPI)
+	}
+
+	if first["cached_at"] != "2026-01-16T12:00:00Z" || first["ttl"] != "2026-01-16T12:05:00Z" {
+		t.Errorf("cached_at/ttl = %v/%v", first["cached_at"], first["ttl"])
+	}
+
+	second := getJSON(t, server, "/status")
+	if second["api"].(map[string]any)["result_from_cache"] != true {
+		t.Errorf("second response not served from cache")
+	}
+
+	if queries := bird.Queries(); len(queries) != 1 {
+		t.Errorf("queries = %v, want a single query", queries)
+	}
+}
+
+func TestServerProtocols(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	result := getJSON(t, server, "/protocols/bgp")
+	protocol := result["protocols"].(map[string]any)["peer_a"].(map[string]any)
+
+	checks := map[string]any{
+		"bird_protocol":    "peer_a",
+		"state":            "up",
+		"state_changed":    "2026-01-16",
+		"description":      "Transit A",
+		"bgp_state":        "Established",
+		"neighbor_address": "192.0.2.1",
+		"neighbor_as":      float64(64500),
+		"hold_timer":       "211/240",
+		"routes":           map[string]any{"imported": float64(1), "filtered": float64(0), "exported": float64(0), "preferred": float64(1)},
+	}
+
+	for key, expected := range checks {
+		if !reflect.DeepEqual(protocol[key], expected) {
+			t.Errorf("protocol[%q] = %v, want %v", key, protocol[key], expected)
+		}
+	}
+
+	changes := protocol["route_changes"].(map[string]any)["import_updates"].(map[string]any)
+	if changes["received"] != float64(2) || changes["accepted"] != float64(1) {
+		t.Errorf("route_changes.import_updates = %v", changes)
+	}
+}
+
+func TestServerRoutes(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	result := getJSON(t, server, "/routes/protocol/peer_a")
+	routes := result["routes"].([]any)
+	if len(routes) != 1 {
+		t.Fatalf("routes = %v", routes)
+	}
+
+	route := routes[0].(map[string]any)
+	if route["network"] != "192.0.2.0/24" || route["learnt_from"] != "192.0.2.10" || route["primary"] != true || route["age"] != "2026-01-16" {
+		t.Errorf("route = %v", route)
+	}
+
+	expectedBGP := map[string]any{
+		"origin":            "IGP",
+		"as_path":           []any{"64500", "64501"},
+		"next_hop":          "192.0.2.1",
+		"local_pref":        "100",
+		"med":               "0",
+		"communities":       []any{[]any{float64(64500), float64(1)}},
+		"large_communities": []any{},
+	}
+	if !reflect.DeepEqual(route["bgp"], expectedBGP) {
+		t.Errorf("route bgp = %v, want %v", route["bgp"], expectedBGP)
+	}
+
+	filtered := getJSON(t, server, "/routes/filtered/peer_a")
+	if routes := filtered["routes"].([]any); len(routes) != 0 {
+		t.Errorf("filtered routes = %v, want none", routes)
+	}
+
+	noexport := getJSON(t, server, "/routes/noexport/peer_a")
+	if routes := noexport["routes"].([]any); len(routes) != 1 {
+		t.Errorf("noexport routes = %v, want 1 route", routes)
+	}
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest("GET", "/routes/protocol/peer_a%20all", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("GET invalid protocol id = %d, want 400", recorder.Code)
+	}
+}
@@ -0,0 +1,153 @@
+package birdparse
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Client struct {
+	Version string
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func Dial(ctx context.Context, network, address string) (*Client, error) {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		conn:   conn,
+		reader: bufio.NewReader(conn),
+	}
+
+	welcome, err := c.read(ctx)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	c.Version = strings.TrimSuffix(strings.TrimSpace(welcome), " ready.")
+
+	return c, nil
+}
+
+func (c *Client) Query(ctx context.Context, command string) (string, error) {
+	if strings.ContainsAny(command, "\r\n") {
+		return "", fmt.Errorf("bird: invalid command %q", command)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.write(ctx, command); err != nil {
+		return "", err
+	}
+
+	return c.read(ctx)
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) write(ctx context.Context, command string) error {
+	stop := c.watch(ctx)
+	defer stop()
+
+	_, err := c.conn.Write([]byte(command + "\n"))
+
+	return c.contextError(ctx, err)
+}
+
+func (c *Client) read(ctx context.Context) (string, error) {
+	stop := c.watch(ctx)
+	defer stop()
+
+	var lines []string
+
+	for {
+		raw, err := c.reader.ReadString('\n')
+		if err != nil {
+			return "", c.contextError(ctx, err)
+		}
+
+		line := strings.TrimRight(raw, "\r\n")
+
+		if strings.HasPrefix(line, " ") {
+			lines = append(lines, line[1:])
+			continue
+		}
+
+		code, text, final, ok := parseReplyLine(line)
+		if !ok {
+			return "", fmt.Errorf("bird: malformed reply line %q", line)
+		}
+
+		if code >= 8000 {
+			return "", &BirdError{Code: code, Message: text}
+		}
+
+		if !final || code != 0 {
+			lines = append(lines, text)
+		}
+
+		if final {
+			return strings.Join(lines, "\n"), nil
+		}
+	}
+}
+
+func (c *Client) watch(ctx context.Context) func() bool {
+	if deadline, ok := ctx.Deadline(); ok {
+		c.conn.SetDeadline(deadline)
+	} else {
+		c.conn.SetDeadline(time.Time{})
+	}
+
+	return context.AfterFunc(ctx, func() {
+		c.conn.SetDeadline(time.Unix(1, 0))
+	})
+}
+
+func (c *Client) contextError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return context.DeadlineExceeded
+	}
+
+	return err
+}
+
+func parseReplyLine(line string) (int, string, bool, bool) {
+	if len(line) < 5 || (line[4] != '-' && line[4] != ' ') {
+		return 0, "", false, false
+	}
+
+	code, err := strconv.Atoi(line[:4])
+	if err != nil {
+		return 0, "", false, false
+	}
+
+	return code, line[5:], line[4] == ' ', true
+}
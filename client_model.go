@@ -0,0 +1,12 @@
+package birdparse
+
+import "fmt"
+
+type BirdError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *BirdError) Error() string {
+	return fmt.Sprintf("bird: %04d %s", e.Code, e.Message)
+}
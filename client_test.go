@@ -0,0 +1,55 @@
+package birdparse
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/LaunchPad-Network/birdparse/internal/birdtest"
+)
+
+func TestClientQuery(t *testing.T) {
+	server := birdtest.NewServer(t)
+	server.Handle("show route", `Table master4:
+10.0.0.0/8           unicast [static4 2026-01-16] * (200)
+        via 10.151.104.1 on eth0`)
+	server.HandleError("show foo", 9001, "syntax error, unexpected CF_SYM_UNDEFINED")
+
+	client, err := Dial(context.Background(), server.Network, server.Address)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer client.Close()
+
+	if client.Version != "BIRD 2.17.1" {
+		t.Errorf("Version = %q, want %q", client.Version, "BIRD 2.17.1")
+	}
+
+	output, err := client.Query(context.Background(), "show route")
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+
+	routes := ParseRoutes(output)
+	if len(routes) != 1 || routes[0].Network != "10.0.0.0/8" {
+		t.Errorf("ParseRoutes(Query()) = %+v, want 10.0.0.0/8", routes)
+	}
+
+	_, err = client.Query(context.Background(), "show foo")
+
+	var birdErr *BirdError
+	if !errors.As(err, &birdErr) || birdErr.Code != 9001 {
+		t.Errorf("Query() error = %v, want BirdError 9001", err)
+	}
+
+	if _, err := client.Query(context.Background(), "show route\nconfigure"); err == nil {
+		t.Errorf("Query() with newline succeeded, want error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.Query(ctx, "show route"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Query() with cancelled context error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/LaunchPad-Network/birdparse/birdwatcher"
+	"github.com/LaunchPad-Network/birdparse/lg"
+)
+
+func main() {
+	listen := flag.String("listen", ":8080", "HTTP listen address")
+	socket := flag.String("socket", "/run/bird/bird.ctl", "BIRD control socket")
+	allow := flag.String("allow", "", "comma separated list of allowed queries (default all)")
+	rate := flag.Float64("rate", 1, "requests per second allowed per client, 0 disables rate limiting")
+	burst := flag.Int("burst", 5, "request burst allowed per client")
+	timeout := flag.Duration("timeout", 10*time.Second, "BIRD query timeout")
+	mode := flag.String("mode", "lg", "API flavour: lg or birdwatcher")
+	cacheTTL := flag.Duration("cache-ttl", 5*time.Minute, "response cache TTL in birdwatcher mode")
+	flag.Parse()
+
+	var handler http.Handler
+	switch *mode {
+	case "lg":
+		server := &lg.Server{
+			Address:   *socket,
+			Timeout:   *timeout,
+			RateLimit: *rate,
+			Burst:     *burst,
+		}
+
+		if *allow != "" {
+			server.Allowed = strings.Split(*allow, ",")
+		}
+
+		handler = server
+	case "birdwatcher":
+		handler = &birdwatcher.Server{
+			Address:  *socket,
+			Timeout:  *timeout,
+			CacheTTL: *cacheTTL,
+		}
+	default:
+		log.Fatalf("unknown mode %q", *mode)
+	}
+
+	httpServer := &http.Server{
+		Addr:              *listen,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	log.Printf("birdlg listening on %s", *listen)
+	log.Fatal(httpServer.ListenAndServe())
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+func writeOutput(w io.Writer, format string, value any, columns []string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case "yaml":
+		return writeYAML(w, value)
+	case "csv":
+		header, rows := tabulate(value, columns)
+		writer := csv.NewWriter(w)
+		writer.Write(header)
+		writer.WriteAll(rows)
+		return writer.Error()
+	case "table":
+		header, rows := tabulate(value, columns)
+		writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, strings.ToUpper(strings.Join(header, "\t")))
+		for _, row := range rows {
+			fmt.Fprintln(writer, strings.Join(row, "\t"))
+		}
+		return writer.Flush()
+	}
+
+	return fmt.Errorf("unknown format %q", format)
+}
+
+func tabulate(value any, columns []string) ([]string, [][]string) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice {
+		slice := reflect.MakeSlice(reflect.SliceOf(v.Type()), 1, 1)
+		slice.Index(0).Set(v)
+		v = slice
+	}
+
+	if len(columns) == 0 {
+		columns = flattenColumns(v.Type().Elem(), "")
+	}
+
+	var rows [][]string
+	for i := 0; i < v.Len(); i++ {
+		cells := make(map[string]string)
+		flattenValue(v.Index(i), "", cells)
+
+		row := make([]string, len(columns))
+		for j, column := range columns {
+			row[j] = cells[column]
+		}
+		rows = append(rows, row)
+	}
+
+	return columns, rows
+}
+
+func flattenColumns(t reflect.Type, prefix string) []string {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var columns []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+
+		ft := field.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		switch {
+		case field.Anonymous && ft.Kind() == reflect.Struct:
+			columns = append(columns, flattenColumns(ft, prefix)...)
+		case ft.Kind() == reflect.Struct:
+			columns = append(columns, flattenColumns(ft, prefix+name+".")...)
+		default:
+			columns = append(columns, prefix+name)
+		}
+	}
+
+	return columns
+}
+
+func flattenValue(v reflect.Value, prefix string, cells map[string]string) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+
+		fv := v.Field(i)
+		ft := field.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		switch {
+		case field.Anonymous && ft.Kind() == reflect.Struct:
+			flattenValue(fv, prefix, cells)
+		case ft.Kind() == reflect.Struct:
+			flattenValue(fv, prefix+name+".", cells)
+		default:
+			cells[prefix+name] = formatCell(fv)
+		}
+	}
+}
+
+func formatCell(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return ""
+		}
+		return formatCell(v.Elem())
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case reflect.Slice:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			item := v.Index(i)
+			switch item.Kind() {
+			case reflect.Slice:
+				parts[i] = "(" + strings.ReplaceAll(formatCell(item), " ", ",") + ")"
+			case reflect.Struct:
+				data, _ := json.Marshal(item.Interface())
+				parts[i] = string(data)
+			default:
+				parts[i] = formatCell(item)
+			}
+		}
+		return strings.Join(parts, " ")
+	}
+
+	data, _ := json.Marshal(v.Interface())
+	return string(data)
+}
+
+func jsonName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+
+	return name, true
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/LaunchPad-Network/birdparse"
+)
+
+type command struct {
+	name    string
+	query   string
+	parse   func(data string) any
+	details func(ctx context.Context, client *birdparse.Client, data string) (string, error)
+	columns []string
+}
+
+var commands = []command{
+	{
+		name:    "routes",
+		query:   "show route all",
+		parse:   func(data string) any { return birdparse.ParseRoutes(data) },
+		columns: []string{"network", "gateway", "interface", "from_protocol", "primary", "metric", "bgp.as_path"},
+	},
+	{
+		name:    "protocols",
+		query:   "show protocols all",
+		parse:   func(data string) any { return birdparse.ParseBGPProtocols(data) },
+		columns: []string{"protocol", "state", "since", "bgp_state", "neighbor_address", "neighbor_as", "routes.imported", "routes.exported"},
+	},
+	{
+		name:    "ospf",
+		query:   "show protocols all",
+		parse:   func(data string) any { return birdparse.ParseOSPFProtocols(data) },
+		details: ospfDetails,
+		columns: []string{"protocol", "state", "router_id", "area_count", "lsa_count", "routes.imported", "routes.exported"},
+	},
+	{
+		name:  "ospf-neighbors",
+		query: "show ospf neighbors",
+		parse: func(data string) any { return birdparse.ParseOSPFNeighbors(data) },
+	},
+	{
+		name:  "bfd",
+		query: "show bfd sessions",
+		parse: func(data string) any { return birdparse.ParseBFDSessions(data) },
+	},
+	{
+		name:  "static",
+		query: "show static",
+		parse: func(data string) any { return birdparse.ParseStaticRoutes(data) },
+	},
+	{
+		name:  "status",
+		query: "show status",
+		parse: func(data string) any { return birdparse.ParseStatus(data) },
+	},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		usage(stderr)
+		return 2
+	}
+
+	cmd, ok := findCommand(args[0])
+	if !ok {
+		fmt.Fprintf(stderr, "birdparse: unknown command %q\n", args[0])
+		usage(stderr)
+		return 2
+	}
+
+	flags := flag.NewFlagSet("birdparse "+cmd.name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "json", "output format: json, yaml, csv or table")
+	file := flags.String("file", "", "read BIRD output from file instead of stdin")
+	socket := flags.String("socket", "", "query the BIRD control socket at this path")
+	query := flags.String("query", cmd.query, "command sent to the control socket")
+	columns := flags.String("columns", "", "comma separated columns for csv and table output")
+	timeout := flags.Duration("timeout", 10*time.Second, "control socket timeout")
+	filter := flags.String("filter", "", "route filter expression, e.g. 'as_path ~ [* 13335 *] && local_pref > 100'")
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	var err error
+	var routeFilter *birdparse.RouteFilter
+	if *filter != "" {
+		if cmd.name != "routes" {
+			fmt.Fprintf(stderr, "birdparse: -filter is only supported for routes\n")
+			return 2
+		}
+
+		if routeFilter, err = birdparse.CompileRouteFilter(*filter); err != nil {
+			fmt.Fprintf(stderr, "birdparse: %v\n", err)
+			return 2
+		}
+	}
+
+	var result any
+	if *socket != "" {
+		result, err = querySocket(cmd, *socket, *query, *timeout)
+	} else {
+		var data string
+		data, err = readInput(stdin, *file)
+		result = cmd.parse(data)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "birdparse: %v\n", err)
+		return 1
+	}
+
+	selected := cmd.columns
+	if *columns != "" {
+		selected = strings.Split(*columns, ",")
+	}
+
+	if routeFilter != nil {
+		result = routeFilter.Filter(result.([]birdparse.Route))
+	}
+
+	if err := writeOutput(stdout, *format, result, selected); err != nil {
+		fmt.Fprintf(stderr, "birdparse: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+func querySocket(cmd command, socket, query string, timeout time.Duration) (any, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client, err := birdparse.Dial(ctx, "unix", socket)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	data, err := client.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.details != nil {
+		details, err := cmd.details(ctx, client, data)
+		if err != nil {
+			return nil, err
+		}
+		data += "\n" + details
+	}
+
+	return cmd.parse(data), nil
+}
+
+func ospfDetails(ctx context.Context, client *birdparse.Client, data string) (string, error) {
+	var queries []string
+	for _, p := range birdparse.ParseOSPFProtocols(data) {
+		queries = append(queries, "show ospf "+p.Protocol)
+	}
+	queries = append(queries, "show status")
+
+	var details []string
+	for _, query := range queries {
+		output, err := client.Query(ctx, query)
+		var birdErr *birdparse.BirdError
+		if errors.As(err, &birdErr) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		details = append(details, output)
+	}
+
+	return strings.Join(details, "\n"), nil
+}
+
+func readInput(stdin io.Reader, file string) (string, error) {
+	if file != "" && file != "-" {
+		data, err := os.ReadFile(file)
+		return string(data), err
+	}
+
+	data, err := io.ReadAll(stdin)
+	return string(data), err
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: birdparse <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-16s %s\n", cmd.name, cmd.query)
+	}
+}
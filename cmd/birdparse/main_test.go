@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/LaunchPad-Network/birdparse/internal/birdtest"
+)
+
+const testRoutes = `BIRD 2.17.1 ready.
+Table master4:
+10.0.0.0/8           unicast [static4 2026-01-16] * (200)
+        via 10.151.104.1 on eth0
+192.0.2.0/24         unicast [bgp1 2026-01-16] * (100) [AS64500i]
+        via 10.151.104.2 on eth0
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 64500
+        BGP.next_hop: 10.151.104.2
+        BGP.local_pref: 100
+        BGP.community: (64500,1) (64500,2)`
+
+func TestRunFormats(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{
+			args: []string{"routes", "-format", "table"},
+			expected: `NETWORK       GATEWAY       INTERFACE  FROM_PROTOCOL  PRIMARY  METRIC  BGP.AS_PATH
+10.0.0.0/8    10.151.104.1  eth0       static4        true     200     
+192.0.2.0/24  10.151.104.2  eth0       bgp1           true     100     64500
+`,
+		},
+		{
+			args:     []string{"routes", "-format", "csv", "-columns", "network", "-filter", "community ~ (64500,*)"},
+			expected: "network\n192.0.2.0/24\n",
+		},
+		{
+			args: []string{"routes", "-format", "csv", "-columns", "network,bgp.communities,bgp.local_pref"},
+			expected: `network,bgp.communities,bgp.local_pref
+10.0.0.0/8,,
+192.0.2.0/24,"(64500,1) (64500,2)",100
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+
+		if code := run(tt.args, strings.NewReader(testRoutes), &stdout, &stderr); code != 0 {
+			t.Fatalf("run(%v) = %d, stderr %s", tt.args, code, stderr.String())
+		}
+
+		if stdout.String() != tt.expected {
+			t.Errorf("run(%v) = %q, want %q", tt.args, stdout.String(), tt.expected)
+		}
+	}
+}
+
+func TestRunJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := run([]string{"routes"}, strings.NewReader(testRoutes), &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, stderr %s", code, stderr.String())
+	}
+
+	var routes []map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &routes); err != nil {
+		t.Fatalf("json output: %v", err)
+	}
+
+	if len(routes) != 2 || routes[1]["network"] != "192.0.2.0/24" {
+		t.Errorf("json output = %v", routes)
+	}
+}
+
+func TestRunYAML(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	data := `BIRD 2.17.1
+Router ID is 192.0.2.1
+Current server time is 2026-01-16 12:00:00.123
+Daemon is up and running`
+
+	if code := run([]string{"status", "-format", "yaml"}, strings.NewReader(data), &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, stderr %s", code, stderr.String())
+	}
+
+	expected := `version: 2.17.1
+router_id: 192.0.2.1
+hostname: ""
+server_time: "2026-01-16 12:00:00.123"
+last_reboot: ""
+last_reconfiguration: ""
+state: up and running
+`
+
+	if stdout.String() != expected {
+		t.Errorf("run() = %q, want %q", stdout.String(), expected)
+	}
+}
+
+func TestQuoteYAML(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"2026-01-16", `"2026-01-16"`},
+		{"2026-01-16 12:00:00", `"2026-01-16 12:00:00"`},
+		{"23:41:27.768", `"23:41:27.768"`},
+		{"2-Way", `"2-Way"`},
+		{"64500", `"64500"`},
+		{"yes", `"yes"`},
+		{"AS64500-peer", "AS64500-peer"},
+		{"192.0.2.0/24", "192.0.2.0/24"},
+		{"Established", "Established"},
+	}
+
+	for _, tt := range tests {
+		if result := quoteYAML(tt.value); result != tt.expected {
+			t.Errorf("quoteYAML(%q) = %s, want %s", tt.value, result, tt.expected)
+		}
+	}
+}
+
+func TestRunSocket(t *testing.T) {
+	server := birdtest.NewServer(t)
+	server.Handle("show route all for 192.0.2.0/24", testRoutes)
+
+	var stdout, stderr bytes.Buffer
+
+	args := []string{"routes", "-socket", server.Address, "-query", "show route all for 192.0.2.0/24", "-format", "csv", "-columns", "network"}
+	if code := run(args, strings.NewReader(""), &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, stderr %s", code, stderr.String())
+	}
+
+	if stdout.String() != "network\n10.0.0.0/8\n192.0.2.0/24\n" {
+		t.Errorf("run() = %q", stdout.String())
+	}
+}
+
+const testOSPFProtocols = `lpnet_ospf OSPF       master4    up     2026-01-16    Running
+  Channel ipv4
+    State:          UP
+    Table:          master4
+    Routes:         12 imported, 0 exported, 10 preferred
+lpnet_ospf6 OSPF       master6    start  10:56:39.545  Alone`
+
+const testOSPFDetails = "lpnet_ospf:\n" +
+	"RFC1583 compatibility: disabled\n" +
+	"Stub router: No\n" +
+	"RT scheduler tick: 1\n" +
+	"Number of areas: 2\n" +
+	"Number of LSAs in DB:\t17\n" +
+	"\tArea: 0.0.0.0 (0) [BACKBONE]\n" +
+	"\t\tNumber of interfaces:\t3"
+
+const testStatus = "BIRD 2.17.1\nRouter ID is 82.39.145.1\nDaemon is up and running"
+
+const testOSPFCSV = "protocol,state,router_id,area_count,lsa_count,routes.imported,routes.exported\n" +
+	"lpnet_ospf,up,82.39.145.1,2,17,12,0\n" +
+	"lpnet_ospf6,start,82.39.145.1,0,0,,\n"
+
+func TestRunSocketOSPF(t *testing.T) {
+	server := birdtest.NewServer(t)
+	server.Handle("show protocols all", testOSPFProtocols)
+	server.Handle("show ospf lpnet_ospf", testOSPFDetails)
+	server.HandleError("show ospf lpnet_ospf6", 8002, "lpnet_ospf6: is not up")
+	server.Handle("show status", testStatus)
+
+	var stdout, stderr bytes.Buffer
+
+	args := []string{"ospf", "-socket", server.Address, "-format", "csv"}
+	if code := run(args, strings.NewReader(""), &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, stderr %s", code, stderr.String())
+	}
+
+	if stdout.String() != testOSPFCSV {
+		t.Errorf("run() = %q, want %q", stdout.String(), testOSPFCSV)
+	}
+
+	queries := []string{"show protocols all", "show ospf lpnet_ospf", "show ospf lpnet_ospf6", "show status"}
+	if !reflect.DeepEqual(server.Queries(), queries) {
+		t.Errorf("queries = %q, want %q", server.Queries(), queries)
+	}
+}
+
+func TestRunOSPFStdin(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	input := strings.Join([]string{"BIRD 2.17.1 ready.", testOSPFProtocols, testOSPFDetails, testStatus}, "\n")
+	if code := run([]string{"ospf", "-format", "csv"}, strings.NewReader(input), &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, stderr %s", code, stderr.String())
+	}
+
+	if stdout.String() != testOSPFCSV {
+		t.Errorf("run() = %q, want %q", stdout.String(), testOSPFCSV)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := run([]string{"unknown"}, strings.NewReader(""), &stdout, &stderr); code != 2 {
+		t.Errorf("run(unknown) = %d, want 2", code)
+	}
+
+	if code := run([]string{"routes", "-format", "xml"}, strings.NewReader(testRoutes), &stdout, &stderr); code != 1 {
+		t.Errorf("run(-format xml) = %d, want 1", code)
+	}
+}
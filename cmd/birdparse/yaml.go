@@ -0,0 +1,143 @@
+package main
+
+import (
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var yamlPlain = regexp.MustCompile(`^[A-Za-z0-9_./][A-Za-z0-9_./:@%+\- ()]*$`)
+
+func writeYAML(w io.Writer, value any) error {
+	scalar, lines, ok := encodeYAML(reflect.ValueOf(value))
+	if ok {
+		lines = []string{scalar}
+	}
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+func encodeYAML(v reflect.Value) (string, []string, bool) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return "null", nil, true
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return "null", nil, true
+		}
+		return encodeYAML(v.Elem())
+	case reflect.String:
+		return quoteYAML(v.String()), nil, true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil, true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), nil, true
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return "[]", nil, true
+		}
+
+		var lines []string
+		for i := 0; i < v.Len(); i++ {
+			lines = append(lines, yamlItem("- ", v.Index(i))...)
+		}
+		return "", lines, false
+	case reflect.Map:
+		if v.Len() == 0 {
+			return "{}", nil, true
+		}
+
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return formatCell(keys[i]) < formatCell(keys[j])
+		})
+
+		var lines []string
+		for _, key := range keys {
+			lines = append(lines, yamlField(quoteYAML(formatCell(key)), v.MapIndex(key))...)
+		}
+		return "", lines, false
+	case reflect.Struct:
+		var lines []string
+		collectYAMLFields(v, &lines)
+		if len(lines) == 0 {
+			return "{}", nil, true
+		}
+		return "", lines, false
+	}
+
+	return quoteYAML(formatCell(v)), nil, true
+}
+
+func collectYAMLFields(v reflect.Value, lines *[]string) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			collectYAMLFields(v.Field(i), lines)
+			continue
+		}
+
+		*lines = append(*lines, yamlField(name, v.Field(i))...)
+	}
+}
+
+func yamlField(key string, v reflect.Value) []string {
+	scalar, lines, ok := encodeYAML(v)
+	if ok {
+		return []string{key + ": " + scalar}
+	}
+
+	result := []string{key + ":"}
+	for _, line := range lines {
+		result = append(result, "  "+line)
+	}
+	return result
+}
+
+func yamlItem(marker string, v reflect.Value) []string {
+	scalar, lines, ok := encodeYAML(v)
+	if ok {
+		return []string{marker + scalar}
+	}
+
+	result := []string{marker + lines[0]}
+	for _, line := range lines[1:] {
+		result = append(result, "  "+line)
+	}
+	return result
+}
+
+func quoteYAML(s string) string {
+	switch strings.ToLower(s) {
+	case "", "true", "false", "yes", "no", "on", "off", "null", "~":
+		return strconv.Quote(s)
+	}
+
+	if !yamlPlain.MatchString(s) || strings.HasSuffix(s, " ") || strings.Contains(s, ":") {
+		return strconv.Quote(s)
+	}
+
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.Quote(s)
+	}
+
+	if s[0] >= '0' && s[0] <= '9' && strings.Contains(s, "-") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
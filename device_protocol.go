@@ -0,0 +1,93 @@
+package birdparse
+
+import (
+	"regexp"
+	"strings"
+)
+
+func ParseDeviceProtocol(data string) DeviceProtocol {
+	result := DeviceProtocol{}
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if m := matchProtocolHeader(line, "Device"); m != nil {
+			result.Protocol = m[1]
+			result.State = m[3]
+
+			result.Connection = strings.TrimSpace(m[5])
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Description:\s+(.*)$`).FindStringSubmatch(line); m != nil {
+			result.Description = m[1]
+			continue
+		}
+	}
+
+	return result
+}
+
+func ParseDeviceProtocols(data string) []DeviceProtocol {
+	var results []DeviceProtocol
+
+	for _, block := range splitProtocolBlocks(data, "Device") {
+		p := ParseDeviceProtocol(block)
+		if p.IsValid() {
+			results = append(results, p)
+		}
+	}
+
+	return results
+}
+
+func ParseDirectProtocol(data string) DirectProtocol {
+	result := DirectProtocol{}
+	var channel *ProtocolChannel
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if m := matchProtocolHeader(line, "Direct"); m != nil {
+			result.Protocol = m[1]
+			result.State = m[3]
+
+			result.Connection = strings.TrimSpace(m[5])
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Description:\s+(.*)$`).FindStringSubmatch(line); m != nil {
+			result.Description = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Channel\s+(\S+)$`).FindStringSubmatch(line); m != nil {
+			result.Channels = append(result.Channels, ProtocolChannel{Name: m[1]})
+			channel = &result.Channels[len(result.Channels)-1]
+			continue
+		}
+
+		if channel != nil {
+			applyChannelLine(channel, line)
+		}
+	}
+
+	return result
+}
+
+func ParseDirectProtocols(data string) []DirectProtocol {
+	var results []DirectProtocol
+
+	for _, block := range splitProtocolBlocks(data, "Direct") {
+		p := ParseDirectProtocol(block)
+		if p.IsValid() {
+			results = append(results, p)
+		}
+	}
+
+	return results
+}
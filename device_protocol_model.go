@@ -0,0 +1,24 @@
+package birdparse
+
+type DeviceProtocol struct {
+	Protocol    string `json:"protocol"`
+	State       string `json:"state"`
+	Connection  string `json:"connection"`
+	Description string `json:"description"`
+}
+
+func (p DeviceProtocol) IsValid() bool {
+	return p.Protocol != ""
+}
+
+type DirectProtocol struct {
+	Protocol    string            `json:"protocol"`
+	State       string            `json:"state"`
+	Connection  string            `json:"connection"`
+	Description string            `json:"description"`
+	Channels    []ProtocolChannel `json:"channels"`
+}
+
+func (p DirectProtocol) IsValid() bool {
+	return p.Protocol != ""
+}
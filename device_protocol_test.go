@@ -0,0 +1,89 @@
+package birdparse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDeviceProtocols(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+device1    Device     ---        up     2026-01-16
+kernel4    Kernel     master4    up     2026-01-16
+  Channel ipv4
+    State:          UP`
+
+	expected := []DeviceProtocol{
+		{
+			Protocol: "device1",
+			State:    "up",
+		},
+	}
+
+	result := ParseDeviceProtocols(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseDeviceProtocols() = %+v, want %+v", result, expected)
+	}
+}
+
+func TestParseDirectProtocols(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+direct1    Direct     ---        up     2026-01-16
+  Description:    loopbacks
+  Channel ipv4
+    State:          UP
+    Table:          master4
+    Preference:     240
+    Input filter:   ACCEPT
+    Output filter:  REJECT
+    Routes:         3 imported, 0 exported, 3 preferred
+  Channel ipv6
+    State:          UP
+    Table:          master6
+    Preference:     240
+    Input filter:   ACCEPT
+    Output filter:  REJECT
+    Routes:         2 imported, 0 exported, 1 preferred`
+
+	expected := []DirectProtocol{
+		{
+			Protocol:    "direct1",
+			State:       "up",
+			Description: "loopbacks",
+			Channels: []ProtocolChannel{
+				{
+					Name:         "ipv4",
+					State:        "UP",
+					Table:        "master4",
+					Preference:   240,
+					InputFilter:  "ACCEPT",
+					OutputFilter: "REJECT",
+					Routes: &BgpProtocolBgpRoutes{
+						Imported:  "3",
+						Exported:  "0",
+						Preferred: "3",
+					},
+				},
+				{
+					Name:         "ipv6",
+					State:        "UP",
+					Table:        "master6",
+					Preference:   240,
+					InputFilter:  "ACCEPT",
+					OutputFilter: "REJECT",
+					Routes: &BgpProtocolBgpRoutes{
+						Imported:  "2",
+						Exported:  "0",
+						Preferred: "1",
+					},
+				},
+			},
+		},
+	}
+
+	result := ParseDirectProtocols(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseDirectProtocols() = %+v, want %+v", result, expected)
+	}
+}
@@ -0,0 +1,280 @@
+package filter
+
+type Node interface {
+	Position() Pos
+}
+
+type Decl interface {
+	Node
+	declNode()
+}
+
+type Stmt interface {
+	Node
+	stmtNode()
+}
+
+type Expr interface {
+	Node
+	exprNode()
+}
+
+type Config struct {
+	Decls []Decl `json:"decls"`
+}
+
+type DefineDecl struct {
+	Pos
+	Name  string `json:"name"`
+	Value Expr   `json:"value"`
+}
+
+type Param struct {
+	Pos
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type FunctionDecl struct {
+	Pos
+	Name       string     `json:"name"`
+	Params     []*Param   `json:"params"`
+	ReturnType string     `json:"return_type"`
+	Locals     []*VarDecl `json:"locals"`
+	Body       *BlockStmt `json:"body"`
+}
+
+type FilterDecl struct {
+	Pos
+	Name   string     `json:"name"`
+	Locals []*VarDecl `json:"locals"`
+	Body   *BlockStmt `json:"body"`
+}
+
+type VarDecl struct {
+	Pos
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value Expr   `json:"value"`
+}
+
+type BlockStmt struct {
+	Pos
+	Stmts []Stmt `json:"stmts"`
+}
+
+type IfStmt struct {
+	Pos
+	Cond Expr `json:"cond"`
+	Then Stmt `json:"then"`
+	Else Stmt `json:"else"`
+}
+
+type CaseClause struct {
+	Pos
+	Labels []Expr `json:"labels"`
+	Body   []Stmt `json:"body"`
+}
+
+type CaseStmt struct {
+	Pos
+	Subject Expr          `json:"subject"`
+	Clauses []*CaseClause `json:"clauses"`
+}
+
+type ForStmt struct {
+	Pos
+	VarType  string `json:"var_type"`
+	Var      string `json:"var"`
+	Iterable Expr   `json:"iterable"`
+	Body     Stmt   `json:"body"`
+}
+
+type AcceptStmt struct {
+	Pos
+	Message []Expr `json:"message"`
+}
+
+type RejectStmt struct {
+	Pos
+	Message []Expr `json:"message"`
+}
+
+type ReturnStmt struct {
+	Pos
+	Value Expr `json:"value"`
+}
+
+type PrintStmt struct {
+	Pos
+	Newline bool   `json:"newline"`
+	Args    []Expr `json:"args"`
+}
+
+type AssignStmt struct {
+	Pos
+	Target Expr `json:"target"`
+	Value  Expr `json:"value"`
+}
+
+type ExprStmt struct {
+	Pos
+	X Expr `json:"x"`
+}
+
+type Ident struct {
+	Pos
+	Name string `json:"name"`
+}
+
+type NumberLit struct {
+	Pos
+	Value string `json:"value"`
+}
+
+type StringLit struct {
+	Pos
+	Value string `json:"value"`
+}
+
+type BoolLit struct {
+	Pos
+	Value bool `json:"value"`
+}
+
+type IPLit struct {
+	Pos
+	Value string `json:"value"`
+}
+
+type PrefixLit struct {
+	Pos
+	Value string `json:"value"`
+	Op    string `json:"op"`
+	Low   int    `json:"low"`
+	High  int    `json:"high"`
+}
+
+type EmptyLit struct {
+	Pos
+	Value string `json:"value"`
+}
+
+type WildcardExpr struct {
+	Pos
+}
+
+type AnyOneExpr struct {
+	Pos
+}
+
+type RangeExpr struct {
+	Pos
+	Low  Expr `json:"low"`
+	High Expr `json:"high"`
+}
+
+type TupleExpr struct {
+	Pos
+	Elems []Expr `json:"elems"`
+}
+
+type SetExpr struct {
+	Pos
+	Items []Expr `json:"items"`
+}
+
+type PathMaskExpr struct {
+	Pos
+	Items []Expr `json:"items"`
+}
+
+type UnaryExpr struct {
+	Pos
+	Op string `json:"op"`
+	X  Expr   `json:"x"`
+}
+
+type BinaryExpr struct {
+	Pos
+	Op string `json:"op"`
+	X  Expr   `json:"x"`
+	Y  Expr   `json:"y"`
+}
+
+type SelectorExpr struct {
+	Pos
+	X   Expr   `json:"x"`
+	Sel string `json:"sel"`
+}
+
+type CallExpr struct {
+	Pos
+	Fun  Expr   `json:"fun"`
+	Args []Expr `json:"args"`
+}
+
+func (*DefineDecl) declNode()   {}
+func (*FunctionDecl) declNode() {}
+func (*FilterDecl) declNode()   {}
+
+func (*VarDecl) stmtNode()    {}
+func (*BlockStmt) stmtNode()  {}
+func (*IfStmt) stmtNode()     {}
+func (*CaseStmt) stmtNode()   {}
+func (*ForStmt) stmtNode()    {}
+func (*AcceptStmt) stmtNode() {}
+func (*RejectStmt) stmtNode() {}
+func (*ReturnStmt) stmtNode() {}
+func (*PrintStmt) stmtNode()  {}
+func (*AssignStmt) stmtNode() {}
+func (*ExprStmt) stmtNode()   {}
+
+func (*Ident) exprNode()        {}
+func (*NumberLit) exprNode()    {}
+func (*StringLit) exprNode()    {}
+func (*BoolLit) exprNode()      {}
+func (*IPLit) exprNode()        {}
+func (*PrefixLit) exprNode()    {}
+func (*EmptyLit) exprNode()     {}
+func (*WildcardExpr) exprNode() {}
+func (*AnyOneExpr) exprNode()   {}
+func (*RangeExpr) exprNode()    {}
+func (*TupleExpr) exprNode()    {}
+func (*SetExpr) exprNode()      {}
+func (*PathMaskExpr) exprNode() {}
+func (*UnaryExpr) exprNode()    {}
+func (*BinaryExpr) exprNode()   {}
+func (*SelectorExpr) exprNode() {}
+func (*CallExpr) exprNode()     {}
+
+func (c *Config) Position() Pos {
+	return Pos{Line: 1, Column: 1}
+}
+
+func (c *Config) Filter(name string) *FilterDecl {
+	for _, decl := range c.Decls {
+		if f, ok := decl.(*FilterDecl); ok && f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func (c *Config) Function(name string) *FunctionDecl {
+	for _, decl := range c.Decls {
+		if f, ok := decl.(*FunctionDecl); ok && f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func (c *Config) Define(name string) *DefineDecl {
+	for _, decl := range c.Decls {
+		if d, ok := decl.(*DefineDecl); ok && d.Name == name {
+			return d
+		}
+	}
+	return nil
+}
@@ -0,0 +1,972 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+)
+
+var typeNames = map[string]bool{
+	"int":        true,
+	"bool":       true,
+	"ip":         true,
+	"prefix":     true,
+	"pair":       true,
+	"quad":       true,
+	"ec":         true,
+	"lc":         true,
+	"rd":         true,
+	"string":     true,
+	"bytestring": true,
+	"bgpmask":    true,
+	"bgppath":    true,
+	"clist":      true,
+	"eclist":     true,
+	"lclist":     true,
+	"enum":       true,
+	"route":      true,
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func Parse(src string) (*Config, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	config := &Config{}
+
+	for p.peek().kind != tokenEOF {
+		tok := p.peek()
+
+		switch {
+		case p.isIdent(tok, "define"):
+			decl, err := p.parseDefine()
+			if err != nil {
+				return nil, err
+			}
+			config.Decls = append(config.Decls, decl)
+		case p.isIdent(tok, "function"):
+			decl, err := p.parseFunction()
+			if err != nil {
+				return nil, err
+			}
+			config.Decls = append(config.Decls, decl)
+		case p.isIdent(tok, "filter") && p.peekAt(1).kind == tokenIdent:
+			decl, err := p.parseFilter()
+			if err != nil {
+				return nil, err
+			}
+			config.Decls = append(config.Decls, decl)
+		default:
+			if err := p.skipStatement(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return config, nil
+}
+
+func ParseExpr(src string) (Expr, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	expr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorf(tok, "unexpected %s", describe(tok))
+	}
+
+	return expr, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(n int) token {
+	if p.pos+n >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+n]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isIdent(tok token, name string) bool {
+	return tok.kind == tokenIdent && !tok.quoted && tok.text == name
+}
+
+func (p *parser) isSymbol(tok token, symbol string) bool {
+	return tok.kind == tokenSymbol && tok.text == symbol
+}
+
+func (p *parser) accept(symbol string) bool {
+	if p.isSymbol(p.peek(), symbol) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) acceptIdent(name string) bool {
+	if p.isIdent(p.peek(), name) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(symbol string) (token, error) {
+	tok := p.peek()
+	if !p.accept(symbol) {
+		return tok, p.errorf(tok, "expected %q, found %s", symbol, describe(tok))
+	}
+	return tok, nil
+}
+
+func (p *parser) expectIdent() (token, error) {
+	tok := p.next()
+	if tok.kind != tokenIdent {
+		return tok, p.errorf(tok, "expected identifier, found %s", describe(tok))
+	}
+	return tok, nil
+}
+
+func (p *parser) errorf(tok token, format string, args ...any) error {
+	return &Error{Pos: tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func describe(tok token) string {
+	switch tok.kind {
+	case tokenEOF:
+		return "end of input"
+	case tokenString:
+		return strconv.Quote(tok.text)
+	}
+	return fmt.Sprintf("%q", tok.text)
+}
+
+func (p *parser) skipStatement() error {
+	depth := 0
+
+	for {
+		tok := p.next()
+
+		switch {
+		case tok.kind == tokenEOF:
+			if depth > 0 {
+				return p.errorf(tok, "unexpected end of input")
+			}
+			return nil
+		case p.isSymbol(tok, "{"):
+			depth++
+		case p.isSymbol(tok, "}"):
+			depth--
+			if depth < 0 {
+				return p.errorf(tok, "unexpected %q", "}")
+			}
+			if depth == 0 {
+				p.accept(";")
+				return nil
+			}
+		case p.isSymbol(tok, ";") && depth == 0:
+			return nil
+		}
+	}
+}
+
+func (p *parser) parseDefine() (*DefineDecl, error) {
+	start := p.next()
+
+	name, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.expect("="); err != nil {
+		return nil, err
+	}
+
+	value, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.expect(";"); err != nil {
+		return nil, err
+	}
+
+	return &DefineDecl{Pos: start.pos, Name: name.text, Value: value}, nil
+}
+
+func (p *parser) parseFunction() (*FunctionDecl, error) {
+	start := p.next()
+
+	name, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	decl := &FunctionDecl{Pos: start.pos, Name: name.text}
+
+	if _, err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	for !p.accept(")") {
+		if len(decl.Params) > 0 {
+			if !p.accept(",") && !p.accept(";") {
+				tok := p.peek()
+				return nil, p.errorf(tok, "expected %q or %q, found %s", ",", ")", describe(tok))
+			}
+		}
+
+		paramStart := p.peek()
+		typ, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+
+		paramName, err := p.expectIdent()
+		if err != nil {
+			return nil, err
+		}
+
+		decl.Params = append(decl.Params, &Param{Pos: paramStart.pos, Type: typ, Name: paramName.text})
+	}
+
+	if p.accept("->") {
+		if decl.ReturnType, err = p.parseType(); err != nil {
+			return nil, err
+		}
+	}
+
+	if decl.Locals, err = p.parseLocals(); err != nil {
+		return nil, err
+	}
+
+	if decl.Body, err = p.parseBlock(); err != nil {
+		return nil, err
+	}
+
+	return decl, nil
+}
+
+func (p *parser) parseFilter() (*FilterDecl, error) {
+	start := p.next()
+
+	name, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	decl := &FilterDecl{Pos: start.pos, Name: name.text}
+
+	if decl.Locals, err = p.parseLocals(); err != nil {
+		return nil, err
+	}
+
+	if decl.Body, err = p.parseBlock(); err != nil {
+		return nil, err
+	}
+
+	return decl, nil
+}
+
+func (p *parser) parseLocals() ([]*VarDecl, error) {
+	var locals []*VarDecl
+
+	for p.isTypeStart() {
+		decl, err := p.parseVarDecl()
+		if err != nil {
+			return nil, err
+		}
+		locals = append(locals, decl)
+	}
+
+	return locals, nil
+}
+
+func (p *parser) isTypeStart() bool {
+	tok := p.peek()
+	if tok.kind != tokenIdent || !typeNames[tok.text] {
+		return false
+	}
+
+	next := p.peekAt(1)
+	return next.kind == tokenIdent
+}
+
+func (p *parser) parseType() (string, error) {
+	tok, err := p.expectIdent()
+	if err != nil {
+		return "", err
+	}
+
+	if !typeNames[tok.text] {
+		return "", p.errorf(tok, "unknown type %q", tok.text)
+	}
+
+	if p.acceptIdent("set") {
+		return tok.text + " set", nil
+	}
+
+	return tok.text, nil
+}
+
+func (p *parser) parseVarDecl() (*VarDecl, error) {
+	start := p.peek()
+
+	typ, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	decl := &VarDecl{Pos: start.pos, Type: typ, Name: name.text}
+
+	if p.accept("=") {
+		if decl.Value, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := p.expect(";"); err != nil {
+		return nil, err
+	}
+
+	return decl, nil
+}
+
+func (p *parser) parseBlock() (*BlockStmt, error) {
+	start, err := p.expect("{")
+	if err != nil {
+		return nil, err
+	}
+
+	block := &BlockStmt{Pos: start.pos}
+
+	for !p.accept("}") {
+		if p.peek().kind == tokenEOF {
+			return nil, p.errorf(p.peek(), "expected %q, found end of input", "}")
+		}
+
+		if p.accept(";") {
+			continue
+		}
+
+		stmt, err := p.parseStmt()
+		if err != nil {
+			return nil, err
+		}
+		block.Stmts = append(block.Stmts, stmt)
+	}
+
+	return block, nil
+}
+
+func (p *parser) parseStmt() (Stmt, error) {
+	tok := p.peek()
+
+	switch {
+	case p.isSymbol(tok, "{"):
+		block, err := p.parseBlock()
+		if err != nil {
+			return nil, err
+		}
+		p.accept(";")
+		return block, nil
+	case p.isIdent(tok, "if"):
+		return p.parseIf()
+	case p.isIdent(tok, "case"):
+		return p.parseCase()
+	case p.isIdent(tok, "for"):
+		return p.parseFor()
+	case p.isIdent(tok, "accept"), p.isIdent(tok, "reject"), p.isIdent(tok, "return"):
+		return p.parseTerminal()
+	case p.isIdent(tok, "print"), p.isIdent(tok, "printn"):
+		return p.parsePrint()
+	case p.isTypeStart():
+		return p.parseVarDecl()
+	}
+
+	target, err := p.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.accept("=") {
+		value, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := p.expect(";"); err != nil {
+			return nil, err
+		}
+
+		return &AssignStmt{Pos: tok.pos, Target: target, Value: value}, nil
+	}
+
+	if _, err := p.expect(";"); err != nil {
+		return nil, err
+	}
+
+	return &ExprStmt{Pos: tok.pos, X: target}, nil
+}
+
+func (p *parser) parseIf() (Stmt, error) {
+	start := p.next()
+
+	cond, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.acceptIdent("then") {
+		tok := p.peek()
+		return nil, p.errorf(tok, "expected %q, found %s", "then", describe(tok))
+	}
+
+	stmt := &IfStmt{Pos: start.pos, Cond: cond}
+
+	if stmt.Then, err = p.parseStmt(); err != nil {
+		return nil, err
+	}
+
+	if p.acceptIdent("else") {
+		if stmt.Else, err = p.parseStmt(); err != nil {
+			return nil, err
+		}
+	}
+
+	return stmt, nil
+}
+
+func (p *parser) parseCase() (Stmt, error) {
+	start := p.next()
+
+	subject, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	stmt := &CaseStmt{Pos: start.pos, Subject: subject}
+	var clause *CaseClause
+
+	for !p.accept("}") {
+		tok := p.peek()
+
+		if tok.kind == tokenEOF {
+			return nil, p.errorf(tok, "expected %q, found end of input", "}")
+		}
+
+		if p.isIdent(tok, "else") && p.isSymbol(p.peekAt(1), ":") {
+			p.pos += 2
+			clause = &CaseClause{Pos: tok.pos}
+			stmt.Clauses = append(stmt.Clauses, clause)
+			continue
+		}
+
+		if labels, ok := p.tryCaseLabels(); ok {
+			clause = &CaseClause{Pos: tok.pos, Labels: labels}
+			stmt.Clauses = append(stmt.Clauses, clause)
+			continue
+		}
+
+		if clause == nil {
+			return nil, p.errorf(tok, "expected case label, found %s", describe(tok))
+		}
+
+		if p.accept(";") {
+			continue
+		}
+
+		body, err := p.parseStmt()
+		if err != nil {
+			return nil, err
+		}
+		clause.Body = append(clause.Body, body)
+	}
+
+	p.accept(";")
+
+	return stmt, nil
+}
+
+func (p *parser) tryCaseLabels() ([]Expr, bool) {
+	saved := p.pos
+
+	var labels []Expr
+	for {
+		label, err := p.parseSetItem()
+		if err != nil {
+			p.pos = saved
+			return nil, false
+		}
+		labels = append(labels, label)
+
+		if p.accept(":") {
+			return labels, true
+		}
+
+		if !p.accept(",") {
+			p.pos = saved
+			return nil, false
+		}
+	}
+}
+
+func (p *parser) parseFor() (Stmt, error) {
+	start := p.next()
+	stmt := &ForStmt{Pos: start.pos}
+
+	if p.isTypeStart() {
+		typ, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		stmt.VarType = typ
+	}
+
+	name, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Var = name.text
+
+	if !p.acceptIdent("in") {
+		tok := p.peek()
+		return nil, p.errorf(tok, "expected %q, found %s", "in", describe(tok))
+	}
+
+	if stmt.Iterable, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+
+	if !p.acceptIdent("do") {
+		tok := p.peek()
+		return nil, p.errorf(tok, "expected %q, found %s", "do", describe(tok))
+	}
+
+	if stmt.Body, err = p.parseStmt(); err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+func (p *parser) parseTerminal() (Stmt, error) {
+	start := p.next()
+
+	if start.text == "return" {
+		var value Expr
+		if !p.isSymbol(p.peek(), ";") {
+			var err error
+			if value, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+		}
+
+		if _, err := p.expect(";"); err != nil {
+			return nil, err
+		}
+
+		return &ReturnStmt{Pos: start.pos, Value: value}, nil
+	}
+
+	var message []Expr
+	for !p.accept(";") {
+		if len(message) > 0 {
+			if _, err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		message = append(message, arg)
+	}
+
+	if start.text == "accept" {
+		return &AcceptStmt{Pos: start.pos, Message: message}, nil
+	}
+
+	return &RejectStmt{Pos: start.pos, Message: message}, nil
+}
+
+func (p *parser) parsePrint() (Stmt, error) {
+	start := p.next()
+	stmt := &PrintStmt{Pos: start.pos, Newline: start.text == "print"}
+
+	for !p.accept(";") {
+		if len(stmt.Args) > 0 {
+			if _, err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Args = append(stmt.Args, arg)
+	}
+
+	return stmt, nil
+}
+
+func (p *parser) parseExpr() (Expr, error) {
+	return p.parseOr()
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	return p.parseBinary([]string{"||"}, p.parseAnd)
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	return p.parseBinary([]string{"&&"}, p.parseComparison)
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	x, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	for _, op := range []string{"=", "==", "!=", "<", "<=", ">", ">=", "~", "!~"} {
+		if p.isSymbol(tok, op) {
+			p.next()
+
+			y, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+
+			if op == "==" {
+				op = "="
+			}
+
+			return &BinaryExpr{Pos: x.Position(), Op: op, X: x, Y: y}, nil
+		}
+	}
+
+	return x, nil
+}
+
+func (p *parser) parseAdditive() (Expr, error) {
+	return p.parseBinary([]string{"+", "-"}, p.parseMultiplicative)
+}
+
+func (p *parser) parseMultiplicative() (Expr, error) {
+	return p.parseBinary([]string{"*", "/"}, p.parseUnary)
+}
+
+func (p *parser) parseBinary(ops []string, operand func() (Expr, error)) (Expr, error) {
+	x, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+
+		matched := ""
+		for _, op := range ops {
+			if p.isSymbol(tok, op) {
+				matched = op
+			}
+		}
+		if matched == "" {
+			return x, nil
+		}
+		p.next()
+
+		y, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		x = &BinaryExpr{Pos: x.Position(), Op: matched, X: x, Y: y}
+	}
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	tok := p.peek()
+
+	if p.accept("!") || p.accept("-") {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &UnaryExpr{Pos: tok.pos, Op: tok.text, X: x}, nil
+	}
+
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (Expr, error) {
+	x, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+
+		switch {
+		case p.isSymbol(tok, "."):
+			p.next()
+
+			sel, err := p.expectIdent()
+			if err != nil {
+				return nil, err
+			}
+
+			x = &SelectorExpr{Pos: x.Position(), X: x, Sel: sel.text}
+		case p.isSymbol(tok, "("):
+			switch x.(type) {
+			case *Ident, *SelectorExpr:
+			default:
+				return x, nil
+			}
+
+			p.next()
+
+			args, err := p.parseList(")")
+			if err != nil {
+				return nil, err
+			}
+
+			x = &CallExpr{Pos: x.Position(), Fun: x, Args: args}
+		default:
+			return x, nil
+		}
+	}
+}
+
+func (p *parser) parseList(end string) ([]Expr, error) {
+	var items []Expr
+
+	for !p.accept(end) {
+		if len(items) > 0 {
+			if _, err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+
+		item, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenNumber:
+		return &NumberLit{Pos: tok.pos, Value: tok.text}, nil
+	case tokenString:
+		return &StringLit{Pos: tok.pos, Value: tok.text}, nil
+	case tokenIP:
+		return &IPLit{Pos: tok.pos, Value: tok.text}, nil
+	case tokenPrefix:
+		return &PrefixLit{Pos: tok.pos, Value: tok.text}, nil
+	case tokenEmpty:
+		return &EmptyLit{Pos: tok.pos, Value: tok.text}, nil
+	case tokenIdent:
+		switch tok.text {
+		case "true", "false":
+			return &BoolLit{Pos: tok.pos, Value: tok.text == "true"}, nil
+		}
+		return &Ident{Pos: tok.pos, Name: tok.text}, nil
+	}
+
+	switch {
+	case p.isSymbol(tok, "("):
+		first, err := p.parseSetItem()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.accept(")") {
+			return first, nil
+		}
+
+		tuple := &TupleExpr{Pos: tok.pos, Elems: []Expr{first}}
+		for !p.accept(")") {
+			if _, err := p.expect(","); err != nil {
+				return nil, err
+			}
+
+			elem, err := p.parseSetItem()
+			if err != nil {
+				return nil, err
+			}
+			tuple.Elems = append(tuple.Elems, elem)
+		}
+
+		return tuple, nil
+	case p.isSymbol(tok, "[") && p.isSymbol(p.peek(), "="):
+		p.next()
+		return p.parsePathMask(tok)
+	case p.isSymbol(tok, "["):
+		set := &SetExpr{Pos: tok.pos}
+		for !p.accept("]") {
+			if len(set.Items) > 0 {
+				if _, err := p.expect(","); err != nil {
+					return nil, err
+				}
+			}
+
+			item, err := p.parseSetItem()
+			if err != nil {
+				return nil, err
+			}
+			set.Items = append(set.Items, item)
+		}
+
+		return set, nil
+	}
+
+	return nil, p.errorf(tok, "unexpected %s", describe(tok))
+}
+
+func (p *parser) parseSetItem() (Expr, error) {
+	tok := p.peek()
+
+	if p.accept("*") {
+		return &WildcardExpr{Pos: tok.pos}, nil
+	}
+
+	if tok.kind == tokenPrefix {
+		after := p.peekAt(1)
+		end := p.peekAt(2)
+
+		if (p.isSymbol(after, "+") || p.isSymbol(after, "-")) &&
+			(p.isSymbol(end, ",") || p.isSymbol(end, "]") || p.isSymbol(end, ":") || p.isSymbol(end, ")")) {
+			p.pos += 2
+			return &PrefixLit{Pos: tok.pos, Value: tok.text, Op: after.text}, nil
+		}
+
+		if p.isSymbol(after, "{") {
+			p.pos += 2
+
+			low, err := p.expectNumber()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(","); err != nil {
+				return nil, err
+			}
+			high, err := p.expectNumber()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect("}"); err != nil {
+				return nil, err
+			}
+
+			return &PrefixLit{Pos: tok.pos, Value: tok.text, Op: "{}", Low: low, High: high}, nil
+		}
+	}
+
+	low, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.accept("..") {
+		high, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		return &RangeExpr{Pos: low.Position(), Low: low, High: high}, nil
+	}
+
+	return low, nil
+}
+
+func (p *parser) parsePathMask(start token) (Expr, error) {
+	mask := &PathMaskExpr{Pos: start.pos}
+
+	for {
+		tok := p.peek()
+
+		switch {
+		case p.isSymbol(tok, "=") && p.isSymbol(p.peekAt(1), "]"):
+			p.pos += 2
+			return mask, nil
+		case p.accept("*"):
+			mask.Items = append(mask.Items, &WildcardExpr{Pos: tok.pos})
+		case p.accept("?"):
+			mask.Items = append(mask.Items, &AnyOneExpr{Pos: tok.pos})
+		case tok.kind == tokenEOF:
+			return nil, p.errorf(tok, "expected %q, found end of input", "=]")
+		default:
+			item, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+
+			if p.accept("..") {
+				high, err := p.parseUnary()
+				if err != nil {
+					return nil, err
+				}
+				item = &RangeExpr{Pos: item.Position(), Low: item, High: high}
+			}
+
+			mask.Items = append(mask.Items, item)
+		}
+	}
+}
+
+func (p *parser) expectNumber() (int, error) {
+	tok := p.next()
+	if tok.kind != tokenNumber {
+		return 0, p.errorf(tok, "expected number, found %s", describe(tok))
+	}
+
+	v, err := strconv.ParseInt(tok.text, 0, 64)
+	if err != nil {
+		return 0, p.errorf(tok, "invalid number %q", tok.text)
+	}
+
+	return int(v), nil
+}
@@ -0,0 +1,347 @@
+package filter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/LaunchPad-Network/birdparse"
+)
+
+const testConfig = `# Example BIRD configuration
+router id 192.0.2.254;
+log syslog all;
+
+define MY_AS = 64500;
+define BOGON_ASNS = [ 0, 23456, 64496..64511, 4200000000..4294967295 ];
+define BOGONS_V6 = [ ::/8+, 2001:db8::/32+, fe80::/10{10,128} ];
+
+/* shared helpers */
+function is_bogon_asn() -> bool {
+	return bgp_path ~ BOGON_ASNS;
+}
+
+function tag_customer(int customer_as; pair marker)
+int tmp;
+{
+	tmp = customer_as;
+	bgp_community.add((MY_AS, 100));
+	bgp_large_community.add((MY_AS, 1, customer_as));
+	bgp_community.delete([(65535, *)]);
+}
+
+filter import_customer_4
+prefix set allowed;
+{
+	allowed = [ 192.0.2.0/24+, 198.51.100.0/22{22,24} ];
+
+	if net !~ allowed then reject "prefix not allowed";
+	if is_bogon_asn() || bgp_path.len > 64 then {
+		print "bogon path from ", from;
+		reject;
+	} else {
+		tag_customer(64501, (MY_AS, 1));
+	}
+
+	if bgp_path ~ [= * 64501 ? =] && !(defined(bgp_med)) then bgp_med = 0;
+
+	case net.len {
+		8..23: reject;
+		24, 25: bgp_local_pref = 200;
+		else: bgp_local_pref = 100; accept;
+	}
+
+	for int asn in bgp_path do {
+		if asn = 0 then reject;
+	}
+
+	accept;
+}
+
+protocol bgp customer1 {
+	local as MY_AS;
+	neighbor 192.0.2.1 as 64501;
+	ipv4 {
+		import filter import_customer_4;
+		export where source = RTS_STATIC;
+	};
+}
+`
+
+func TestParseConfig(t *testing.T) {
+	config, err := Parse(testConfig)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	var names []string
+	for _, decl := range config.Decls {
+		switch d := decl.(type) {
+		case *DefineDecl:
+			names = append(names, "define "+d.Name)
+		case *FunctionDecl:
+			names = append(names, "function "+d.Name)
+		case *FilterDecl:
+			names = append(names, "filter "+d.Name)
+		}
+	}
+
+	expectedNames := []string{
+		"define MY_AS",
+		"define BOGON_ASNS",
+		"define BOGONS_V6",
+		"function is_bogon_asn",
+		"function tag_customer",
+		"filter import_customer_4",
+	}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("decls = %v, want %v", names, expectedNames)
+	}
+
+	bogons := config.Define("BOGONS_V6").Value.(*SetExpr)
+	expectedBogons := []Expr{
+		&PrefixLit{Pos: Pos{Offset: 184, Line: 7, Column: 22}, Value: "::/8", Op: "+"},
+		&PrefixLit{Pos: Pos{Offset: 191, Line: 7, Column: 29}, Value: "2001:db8::/32", Op: "+"},
+		&PrefixLit{Pos: Pos{Offset: 207, Line: 7, Column: 45}, Value: "fe80::/10", Op: "{}", Low: 10, High: 128},
+	}
+	if !reflect.DeepEqual(bogons.Items, expectedBogons) {
+		t.Errorf("BOGONS_V6 = %#v, want %#v", bogons.Items, expectedBogons)
+	}
+
+	fn := config.Function("tag_customer")
+	expectedParams := []*Param{
+		{Pos: Pos{Offset: 340, Line: 14, Column: 23}, Type: "int", Name: "customer_as"},
+		{Pos: Pos{Offset: 357, Line: 14, Column: 40}, Type: "pair", Name: "marker"},
+	}
+	if !reflect.DeepEqual(fn.Params, expectedParams) {
+		t.Errorf("tag_customer params = %+v, want %+v", fn.Params, expectedParams)
+	}
+	if len(fn.Locals) != 1 || fn.Locals[0].Name != "tmp" || len(fn.Body.Stmts) != 4 {
+		t.Errorf("tag_customer = %+v", fn)
+	}
+
+	if ret := config.Function("is_bogon_asn").ReturnType; ret != "bool" {
+		t.Errorf("is_bogon_asn return type = %q, want bool", ret)
+	}
+
+	add := fn.Body.Stmts[1].(*ExprStmt).X.(*CallExpr)
+	expectedAdd := &CallExpr{
+		Pos: Pos{Offset: 402, Line: 18, Column: 2},
+		Fun: &SelectorExpr{
+			Pos: Pos{Offset: 402, Line: 18, Column: 2},
+			X:   &Ident{Pos: Pos{Offset: 402, Line: 18, Column: 2}, Name: "bgp_community"},
+			Sel: "add",
+		},
+		Args: []Expr{
+			&TupleExpr{
+				Pos: Pos{Offset: 420, Line: 18, Column: 20},
+				Elems: []Expr{
+					&Ident{Pos: Pos{Offset: 421, Line: 18, Column: 21}, Name: "MY_AS"},
+					&NumberLit{Pos: Pos{Offset: 428, Line: 18, Column: 28}, Value: "100"},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(add, expectedAdd) {
+		t.Errorf("bgp_community.add = %#v, want %#v", add, expectedAdd)
+	}
+
+	filter := config.Filter("import_customer_4")
+	if filter == nil {
+		t.Fatalf("Filter(import_customer_4) = nil")
+	}
+
+	if len(filter.Locals) != 1 || filter.Locals[0].Type != "prefix set" {
+		t.Errorf("filter locals = %+v", filter.Locals)
+	}
+
+	stmts := filter.Body.Stmts
+	if len(stmts) != 7 {
+		t.Fatalf("filter statements = %d, want 7", len(stmts))
+	}
+
+	reject := stmts[1].(*IfStmt)
+	if cond := reject.Cond.(*BinaryExpr); cond.Op != "!~" || cond.Pos != (Pos{Offset: 632, Line: 28, Column: 5}) {
+		t.Errorf("first if condition = %+v", cond)
+	}
+	if msg := reject.Then.(*RejectStmt).Message; len(msg) != 1 || msg[0].(*StringLit).Value != "prefix not allowed" {
+		t.Errorf("reject message = %+v", msg)
+	}
+
+	mask := stmts[3].(*IfStmt).Cond.(*BinaryExpr).X.(*BinaryExpr).Y.(*PathMaskExpr)
+	if len(mask.Items) != 3 {
+		t.Errorf("path mask = %+v", mask.Items)
+	} else {
+		_, wildcard := mask.Items[0].(*WildcardExpr)
+		_, one := mask.Items[2].(*AnyOneExpr)
+		if !wildcard || !one || mask.Items[1].(*NumberLit).Value != "64501" {
+			t.Errorf("path mask = %+v", mask.Items)
+		}
+	}
+
+	cs := stmts[4].(*CaseStmt)
+	if len(cs.Clauses) != 3 {
+		t.Fatalf("case clauses = %d, want 3", len(cs.Clauses))
+	}
+	if _, ok := cs.Clauses[0].Labels[0].(*RangeExpr); !ok {
+		t.Errorf("first case label = %#v, want range", cs.Clauses[0].Labels[0])
+	}
+	if len(cs.Clauses[1].Labels) != 2 || cs.Clauses[2].Labels != nil || len(cs.Clauses[2].Body) != 2 {
+		t.Errorf("case clauses = %+v %+v", cs.Clauses[1], cs.Clauses[2])
+	}
+
+	loop := stmts[5].(*ForStmt)
+	if loop.VarType != "int" || loop.Var != "asn" {
+		t.Errorf("for statement = %+v", loop)
+	}
+
+	if _, ok := stmts[6].(*AcceptStmt); !ok {
+		t.Errorf("last statement = %#v, want accept", stmts[6])
+	}
+}
+
+func TestWalk(t *testing.T) {
+	config, err := Parse(testConfig)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	called := map[string]bool{}
+	Walk(config.Filter("import_customer_4"), func(node Node) bool {
+		if call, ok := node.(*CallExpr); ok {
+			if ident, ok := call.Fun.(*Ident); ok {
+				called[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	expected := map[string]bool{"is_bogon_asn": true, "tag_customer": true, "defined": true}
+	if !reflect.DeepEqual(called, expected) {
+		t.Errorf("called = %v, want %v", called, expected)
+	}
+}
+
+func TestApprovedFilters(t *testing.T) {
+	config, err := Parse(testConfig)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	protocols := []birdparse.BgpProtocol{
+		{Protocol: "customer1", InputFilter: "import_customer_4", OutputFilter: "(unnamed)"},
+		{Protocol: "customer2", InputFilter: "import_customer_6", OutputFilter: "(unnamed)"},
+	}
+
+	var missing []string
+	for _, p := range protocols {
+		if config.Filter(p.InputFilter) == nil {
+			missing = append(missing, p.Protocol)
+		}
+	}
+
+	if !reflect.DeepEqual(missing, []string{"customer2"}) {
+		t.Errorf("protocols without a known filter = %v", missing)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		src string
+		pos Pos
+	}{
+		{"filter f { accept }", Pos{Offset: 18, Line: 1, Column: 19}},
+		{"filter f {\n  if net ~ [ 10.0.0.0/8+ ] reject;\n}", Pos{Offset: 38, Line: 2, Column: 28}},
+		{"function f(int) { }", Pos{Offset: 14, Line: 1, Column: 15}},
+		{"define X = ;", Pos{Offset: 11, Line: 1, Column: 12}},
+		{"filter f {\n  print \"unterminated;\n}", Pos{Offset: 19, Line: 2, Column: 9}},
+		{"filter f {\n  bgp_med = 1 @ 2;\n}", Pos{Offset: 25, Line: 2, Column: 15}},
+		{"protocol bgp x {\n  ipv4 {\n", Pos{Offset: 26, Line: 3, Column: 1}},
+		{"filter f { case net.len { reject; } }", Pos{Offset: 26, Line: 1, Column: 27}},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.src)
+
+		var parseErr *Error
+		if !errors.As(err, &parseErr) {
+			t.Errorf("Parse(%q) error = %v, want *Error", tt.src, err)
+			continue
+		}
+
+		if parseErr.Pos != tt.pos {
+			t.Errorf("Parse(%q) error at %+v, want %+v (%v)", tt.src, parseErr.Pos, tt.pos, err)
+		}
+	}
+}
+
+func TestParseExpr(t *testing.T) {
+	expr, err := ParseExpr(`bgp_path ~ [= * 13335 * =] && (65000, 10..20) ~ bgp_community`)
+	if err != nil {
+		t.Fatalf("ParseExpr() error = %v", err)
+	}
+
+	and := expr.(*BinaryExpr)
+	if and.Op != "&&" {
+		t.Fatalf("top-level operator = %q, want &&", and.Op)
+	}
+
+	tuple := and.Y.(*BinaryExpr).X.(*TupleExpr)
+	if _, ok := tuple.Elems[1].(*RangeExpr); !ok || tuple.Pos.Column != 31 {
+		t.Errorf("community pattern = %#v", tuple)
+	}
+}
+
+func TestParseRejectMessageList(t *testing.T) {
+	config, err := Parse("filter bogons {\n  if net ~ [ 10.0.0.0/8+ ] then reject \"bogon \", net;\n  accept;\n}")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	stmts := config.Filter("bogons").Body.Stmts
+
+	msg := stmts[0].(*IfStmt).Then.(*RejectStmt).Message
+	if len(msg) != 2 || msg[0].(*StringLit).Value != "bogon " || msg[1].(*Ident).Name != "net" {
+		t.Errorf("reject message = %+v", msg)
+	}
+
+	if accept := stmts[1].(*AcceptStmt); accept.Message != nil {
+		t.Errorf("accept message = %+v, want none", accept.Message)
+	}
+}
+
+func TestParseQuotedSymbols(t *testing.T) {
+	src := `define 'as-set' = [ 64500, 64501 ];
+
+filter 'import-peer' {
+  if bgp_path.first ~ 'as-set' then accept;
+  reject;
+}
+
+protocol bgp 'peer-1' {
+  ipv4 { import filter 'import-peer'; };
+}`
+
+	config, err := Parse(src)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if config.Define("as-set") == nil {
+		t.Errorf("Define(as-set) = nil")
+	}
+
+	filter := config.Filter("import-peer")
+	if filter == nil {
+		t.Fatalf("Filter(import-peer) = nil")
+	}
+
+	cond := filter.Body.Stmts[0].(*IfStmt).Cond.(*BinaryExpr)
+	if ident, ok := cond.Y.(*Ident); !ok || ident.Name != "as-set" || ident.Pos != (Pos{Offset: 82, Line: 4, Column: 23}) {
+		t.Errorf("condition operand = %#v", cond.Y)
+	}
+
+	if _, err := Parse("filter 'unterminated {\n}"); err == nil {
+		t.Errorf("Parse() with unterminated symbol name succeeded")
+	}
+}
@@ -0,0 +1,233 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type Pos struct {
+	Offset int `json:"offset"`
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+func (p Pos) Position() Pos {
+	return p
+}
+
+func (p Pos) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+type Error struct {
+	Pos Pos    `json:"pos"`
+	Msg string `json:"msg"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenIP
+	tokenPrefix
+	tokenEmpty
+	tokenSymbol
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	pos    Pos
+	quoted bool
+}
+
+var (
+	ipv4RE   = regexp.MustCompile(`^\d+\.\d+\.\d+\.\d+`)
+	ipv6RE   = regexp.MustCompile(`^(?:[0-9a-fA-F]*::|(?:[0-9a-fA-F]*:){3,})(?:\d+\.\d+\.\d+\.\d+|[0-9a-fA-F]*(?::[0-9a-fA-F]+)*)`)
+	lenRE    = regexp.MustCompile(`^/\d+`)
+	numRE    = regexp.MustCompile(`^(?:0x[0-9a-fA-F]+|\d+)`)
+	identRE  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*`)
+	quotedRE = regexp.MustCompile(`^'[^'\n]+'`)
+)
+
+var symbols = []string{
+	"..", "==", "!=", "!~", "<=", ">=", "&&", "||", "->",
+	"{", "}", "(", ")", "[", "]", ";", ",", ":", "=", "<", ">", "~", "!", "+", "-", "*", "/", ".", "?",
+}
+
+type lexer struct {
+	src    string
+	offset int
+	line   int
+	column int
+}
+
+func tokenize(src string) ([]token, error) {
+	l := &lexer{src: src, line: 1, column: 1}
+
+	var tokens []token
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, tok)
+		if tok.kind == tokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) pos() Pos {
+	return Pos{Offset: l.offset, Line: l.line, Column: l.column}
+}
+
+func (l *lexer) advance(n int) string {
+	text := l.src[l.offset : l.offset+n]
+
+	for _, c := range text {
+		if c == '\n' {
+			l.line++
+			l.column = 1
+		} else {
+			l.column++
+		}
+	}
+	l.offset += n
+
+	return text
+}
+
+func (l *lexer) next() (token, error) {
+	if err := l.skipSpace(); err != nil {
+		return token{}, err
+	}
+
+	pos := l.pos()
+	rest := l.src[l.offset:]
+
+	if rest == "" {
+		return token{kind: tokenEOF, pos: pos}, nil
+	}
+
+	if rest[0] == '"' {
+		return l.string()
+	}
+
+	if rest[0] == '\'' {
+		m := quotedRE.FindString(rest)
+		if m == "" {
+			return token{}, &Error{Pos: pos, Msg: "unterminated symbol name"}
+		}
+		return token{kind: tokenIdent, text: l.advance(len(m))[1 : len(m)-1], pos: pos, quoted: true}, nil
+	}
+
+	if strings.HasPrefix(rest, "-empty-") || strings.HasPrefix(rest, "+empty+") {
+		return token{kind: tokenEmpty, text: l.advance(7), pos: pos}, nil
+	}
+
+	ident := identRE.FindString(rest)
+
+	if m := ipv6RE.FindString(rest); m != "" && len(m) > len(ident) {
+		return l.address(m, pos), nil
+	}
+
+	if m := ipv4RE.FindString(rest); m != "" {
+		return l.address(m, pos), nil
+	}
+
+	if ident != "" {
+		return token{kind: tokenIdent, text: l.advance(len(ident)), pos: pos}, nil
+	}
+
+	if m := numRE.FindString(rest); m != "" {
+		return token{kind: tokenNumber, text: l.advance(len(m)), pos: pos}, nil
+	}
+
+	for _, symbol := range symbols {
+		if strings.HasPrefix(rest, symbol) {
+			return token{kind: tokenSymbol, text: l.advance(len(symbol)), pos: pos}, nil
+		}
+	}
+
+	return token{}, &Error{Pos: pos, Msg: fmt.Sprintf("unexpected character %q", rest[0])}
+}
+
+func (l *lexer) address(m string, pos Pos) token {
+	kind := tokenIP
+	if length := lenRE.FindString(l.src[l.offset+len(m):]); length != "" {
+		m += length
+		kind = tokenPrefix
+	}
+
+	return token{kind: kind, text: l.advance(len(m)), pos: pos}
+}
+
+func (l *lexer) string() (token, error) {
+	pos := l.pos()
+	l.advance(1)
+
+	var b strings.Builder
+	for {
+		if l.offset >= len(l.src) || l.src[l.offset] == '\n' {
+			return token{}, &Error{Pos: pos, Msg: "unterminated string"}
+		}
+
+		c := l.src[l.offset]
+		if c == '"' {
+			l.advance(1)
+			return token{kind: tokenString, text: b.String(), pos: pos}, nil
+		}
+
+		if c == '\\' && l.offset+1 < len(l.src) {
+			l.advance(1)
+			c = l.src[l.offset]
+			switch c {
+			case 'n':
+				c = '\n'
+			case 't':
+				c = '\t'
+			}
+		}
+
+		b.WriteByte(c)
+		l.advance(1)
+	}
+}
+
+func (l *lexer) skipSpace() error {
+	for l.offset < len(l.src) {
+		rest := l.src[l.offset:]
+
+		switch {
+		case rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\n' || rest[0] == '\r':
+			l.advance(1)
+		case rest[0] == '#':
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			l.advance(end)
+		case strings.HasPrefix(rest, "/*"):
+			pos := l.pos()
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				return &Error{Pos: pos, Msg: "unterminated comment"}
+			}
+			l.advance(end + 4)
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package filter
+
+func Walk(node Node, fn func(node Node) bool) {
+	if node == nil || !fn(node) {
+		return
+	}
+
+	walkExprs := func(exprs []Expr) {
+		for _, expr := range exprs {
+			if expr != nil {
+				Walk(expr, fn)
+			}
+		}
+	}
+
+	walkStmts := func(stmts []Stmt) {
+		for _, stmt := range stmts {
+			if stmt != nil {
+				Walk(stmt, fn)
+			}
+		}
+	}
+
+	walkExpr := func(expr Expr) {
+		if expr != nil {
+			Walk(expr, fn)
+		}
+	}
+
+	walkStmt := func(stmt Stmt) {
+		if stmt != nil {
+			Walk(stmt, fn)
+		}
+	}
+
+	switch n := node.(type) {
+	case *Config:
+		for _, decl := range n.Decls {
+			Walk(decl, fn)
+		}
+	case *DefineDecl:
+		walkExpr(n.Value)
+	case *FunctionDecl:
+		for _, param := range n.Params {
+			Walk(param, fn)
+		}
+		for _, local := range n.Locals {
+			Walk(local, fn)
+		}
+		if n.Body != nil {
+			Walk(n.Body, fn)
+		}
+	case *FilterDecl:
+		for _, local := range n.Locals {
+			Walk(local, fn)
+		}
+		if n.Body != nil {
+			Walk(n.Body, fn)
+		}
+	case *VarDecl:
+		walkExpr(n.Value)
+	case *BlockStmt:
+		walkStmts(n.Stmts)
+	case *IfStmt:
+		walkExpr(n.Cond)
+		walkStmt(n.Then)
+		walkStmt(n.Else)
+	case *CaseStmt:
+		walkExpr(n.Subject)
+		for _, clause := range n.Clauses {
+			Walk(clause, fn)
+		}
+	case *CaseClause:
+		walkExprs(n.Labels)
+		walkStmts(n.Body)
+	case *ForStmt:
+		walkExpr(n.Iterable)
+		walkStmt(n.Body)
+	case *AcceptStmt:
+		walkExprs(n.Message)
+	case *RejectStmt:
+		walkExprs(n.Message)
+	case *ReturnStmt:
+		walkExpr(n.Value)
+	case *PrintStmt:
+		walkExprs(n.Args)
+	case *AssignStmt:
+		walkExpr(n.Target)
+		walkExpr(n.Value)
+	case *ExprStmt:
+		walkExpr(n.X)
+	case *RangeExpr:
+		walkExpr(n.Low)
+		walkExpr(n.High)
+	case *TupleExpr:
+		walkExprs(n.Elems)
+	case *SetExpr:
+		walkExprs(n.Items)
+	case *PathMaskExpr:
+		walkExprs(n.Items)
+	case *UnaryExpr:
+		walkExpr(n.X)
+	case *BinaryExpr:
+		walkExpr(n.X)
+		walkExpr(n.Y)
+	case *SelectorExpr:
+		walkExpr(n.X)
+	case *CallExpr:
+		walkExpr(n.Fun)
+		walkExprs(n.Args)
+	}
+}
@@ -0,0 +1,240 @@
+package birdparse
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+func ParseFlowSpecRoutes(data string) []FlowSpecRoute {
+	routes := []FlowSpecRoute{}
+
+	for _, route := range ParseRoutes(data) {
+		if !strings.HasPrefix(route.Network, "flow") {
+			continue
+		}
+
+		flowRoute := FlowSpecRoute{
+			Route: route,
+			Flow:  ParseFlowSpec(route.Network),
+		}
+
+		if value, ok := route.Attribute("BGP.ext_community"); ok {
+			flowRoute.Actions = parseFlowSpecActions(value)
+		}
+
+		routes = append(routes, flowRoute)
+	}
+
+	return routes
+}
+
+func ParseFlowSpec(nlri string) FlowSpec {
+	var flow FlowSpec
+
+	m := regexp.MustCompile(`^(flow[46])\s+\{(.*)\}$`).FindStringSubmatch(strings.TrimSpace(nlri))
+	if m == nil {
+		return flow
+	}
+
+	flow.Family = m[1]
+
+	for _, part := range strings.Split(m[2], ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(dst|src)\s+(\S+)(?:\s+offset\s+(\d+))?$`).FindStringSubmatch(part); m != nil {
+			if m[1] == "dst" {
+				flow.Destination = m[2]
+				flow.DestinationOffset = atoi(m[3])
+			} else {
+				flow.Source = m[2]
+				flow.SourceOffset = atoi(m[3])
+			}
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(proto|next header|port|dport|sport|icmp type|icmp code|length|dscp)\s+(.+)$`).FindStringSubmatch(part); m != nil {
+			matches := parseFlowSpecNumeric(m[2])
+
+			switch m[1] {
+			case "proto", "next header":
+				flow.Protocol = matches
+			case "port":
+				flow.Port = matches
+			case "dport":
+				flow.DestinationPort = matches
+			case "sport":
+				flow.SourcePort = matches
+			case "icmp type":
+				flow.ICMPType = matches
+			case "icmp code":
+				flow.ICMPCode = matches
+			case "length":
+				flow.Length = matches
+			case "dscp":
+				flow.DSCP = matches
+			}
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(tcp flags|fragment|label)\s+(.+)$`).FindStringSubmatch(part); m != nil {
+			matches := parseFlowSpecBitmask(m[2])
+
+			switch m[1] {
+			case "tcp flags":
+				flow.TCPFlags = matches
+			case "fragment":
+				flow.Fragment = matches
+			case "label":
+				flow.Label = matches
+			}
+		}
+	}
+
+	return flow
+}
+
+func parseFlowSpecNumeric(expression string) []FlowSpecNumericMatch {
+	matches := []FlowSpecNumericMatch{}
+	and := false
+	operator := ""
+
+	for _, token := range strings.Fields(strings.ReplaceAll(expression, ",", " , ")) {
+		switch token {
+		case "&&":
+			and = true
+			continue
+		case "||", ",":
+			and = false
+			continue
+		case "=", "!=", "<", "<=", ">", ">=":
+			operator = token
+			continue
+		}
+
+		if bounds := strings.SplitN(token, "..", 2); len(bounds) == 2 {
+			low, err1 := strconv.ParseInt(bounds[0], 0, 64)
+			high, err2 := strconv.ParseInt(bounds[1], 0, 64)
+			if err1 == nil && err2 == nil {
+				matches = append(matches,
+					FlowSpecNumericMatch{And: and, Operator: ">=", Value: int(low)},
+					FlowSpecNumericMatch{And: true, Operator: "<=", Value: int(high)},
+				)
+			}
+		} else if value, err := strconv.ParseInt(token, 0, 64); err == nil {
+			if operator == "" {
+				operator = "="
+			}
+			matches = append(matches, FlowSpecNumericMatch{And: and, Operator: operator, Value: int(value)})
+		}
+
+		and = false
+		operator = ""
+	}
+
+	return matches
+}
+
+func parseFlowSpecBitmask(expression string) []FlowSpecBitmaskMatch {
+	matches := []FlowSpecBitmaskMatch{}
+	and := false
+
+	fragmentBits := map[string]int{
+		"dont_fragment":  1,
+		"is_fragment":    2,
+		"first_fragment": 4,
+		"last_fragment":  8,
+	}
+
+	for _, token := range strings.Fields(strings.ReplaceAll(expression, ",", " , ")) {
+		switch token {
+		case "&&":
+			and = true
+			continue
+		case "||", ",":
+			and = false
+			continue
+		}
+
+		match := FlowSpecBitmaskMatch{And: and}
+		if strings.HasPrefix(token, "!") {
+			match.Not = true
+			token = token[1:]
+		}
+
+		if bit, ok := fragmentBits[token]; ok {
+			match.Value = bit
+			match.Mask = bit
+		} else if m := regexp.MustCompile(`^(0x[0-9a-fA-F]+|\d+)(?:/(0x[0-9a-fA-F]+|\d+))?$`).FindStringSubmatch(token); m != nil {
+			value, _ := strconv.ParseInt(m[1], 0, 64)
+			match.Value = int(value)
+			match.Mask = int(value)
+			if m[2] != "" {
+				mask, _ := strconv.ParseInt(m[2], 0, 64)
+				match.Mask = int(mask)
+			}
+		} else {
+			continue
+		}
+
+		matches = append(matches, match)
+		and = false
+	}
+
+	return matches
+}
+
+func parseFlowSpecActions(extCommunities string) []FlowSpecAction {
+	actions := []FlowSpecAction{}
+
+	for _, m := range regexp.MustCompile(`\(generic,\s*(0x[0-9a-fA-F]+),\s*(0x[0-9a-fA-F]+)\)`).FindAllStringSubmatch(extCommunities, -1) {
+		hi, _ := strconv.ParseUint(m[1], 0, 32)
+		lo, _ := strconv.ParseUint(m[2], 0, 32)
+
+		switch hi >> 16 {
+		case 0x8006:
+			actions = append(actions, FlowSpecAction{
+				Type: "traffic-rate",
+				ASN:  int(hi & 0xffff),
+				Rate: math.Float32frombits(uint32(lo)),
+			})
+		case 0x8007:
+			actions = append(actions, FlowSpecAction{
+				Type:     "traffic-action",
+				Sample:   lo&0x02 != 0,
+				Terminal: lo&0x01 != 0,
+			})
+		case 0x8008:
+			actions = append(actions, FlowSpecAction{
+				Type:   "redirect",
+				ASN:    int(hi & 0xffff),
+				Target: fmt.Sprintf("%d:%d", hi&0xffff, lo),
+			})
+		case 0x8108:
+			ip := (hi&0xffff)<<16 | lo>>16
+			actions = append(actions, FlowSpecAction{
+				Type:   "redirect",
+				Target: fmt.Sprintf("%d.%d.%d.%d:%d", ip>>24, ip>>16&0xff, ip>>8&0xff, ip&0xff, lo&0xffff),
+			})
+		case 0x8208:
+			asn := (hi&0xffff)<<16 | lo>>16
+			actions = append(actions, FlowSpecAction{
+				Type:   "redirect",
+				ASN:    int(asn),
+				Target: fmt.Sprintf("%d:%d", asn, lo&0xffff),
+			})
+		case 0x8009:
+			actions = append(actions, FlowSpecAction{
+				Type: "traffic-marking",
+				DSCP: int(lo & 0x3f),
+			})
+		}
+	}
+
+	return actions
+}
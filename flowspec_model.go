@@ -0,0 +1,59 @@
+package birdparse
+
+type FlowSpecNumericMatch struct {
+	And      bool   `json:"and"`
+	Operator string `json:"operator"`
+	Value    int    `json:"value"`
+}
+
+type FlowSpecBitmaskMatch struct {
+	And   bool `json:"and"`
+	Not   bool `json:"not"`
+	Value int  `json:"value"`
+	Mask  int  `json:"mask"`
+}
+
+type FlowSpec struct {
+	Family            string                 `json:"family"`
+	Destination       string                 `json:"destination"`
+	DestinationOffset int                    `json:"destination_offset"`
+	Source            string                 `json:"source"`
+	SourceOffset      int                    `json:"source_offset"`
+	Protocol          []FlowSpecNumericMatch `json:"protocol"`
+	Port              []FlowSpecNumericMatch `json:"port"`
+	DestinationPort   []FlowSpecNumericMatch `json:"destination_port"`
+	SourcePort        []FlowSpecNumericMatch `json:"source_port"`
+	ICMPType          []FlowSpecNumericMatch `json:"icmp_type"`
+	ICMPCode          []FlowSpecNumericMatch `json:"icmp_code"`
+	TCPFlags          []FlowSpecBitmaskMatch `json:"tcp_flags"`
+	Length            []FlowSpecNumericMatch `json:"length"`
+	DSCP              []FlowSpecNumericMatch `json:"dscp"`
+	Fragment          []FlowSpecBitmaskMatch `json:"fragment"`
+	Label             []FlowSpecBitmaskMatch `json:"label"`
+}
+
+type FlowSpecAction struct {
+	Type     string  `json:"type"`
+	ASN      int     `json:"asn"`
+	Rate     float32 `json:"rate"`
+	Sample   bool    `json:"sample"`
+	Terminal bool    `json:"terminal"`
+	Target   string  `json:"target"`
+	DSCP     int     `json:"dscp"`
+}
+
+type FlowSpecRoute struct {
+	Route
+	Flow    FlowSpec         `json:"flow"`
+	Actions []FlowSpecAction `json:"actions"`
+}
+
+func (r FlowSpecRoute) IsDiscard() bool {
+	for _, action := range r.Actions {
+		if action.Type == "traffic-rate" && action.Rate == 0 {
+			return true
+		}
+	}
+
+	return false
+}
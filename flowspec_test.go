@@ -0,0 +1,127 @@
+package birdparse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFlowSpecRoutes(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+Table flow4:
+flow4 { dst 192.0.2.0/24; proto 6; dport 80,443; sport 1024..65535; tcp flags 0x2/0x12; fragment !is_fragment; } [flow_rr 2026-01-16] * (100) [AS64500i]
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 64500
+        BGP.local_pref: 100
+        BGP.ext_community: (generic, 0x80060000, 0x0) (generic, 0x82080000, 0xfde80064)
+flow4 { dst 198.51.100.7/32; proto 17; length > 1000 && < 1500; } [flow_rr 2026-01-16] * (100) [AS64500i]
+        Type: BGP univ
+        BGP.ext_community: (generic, 0x8006fc00, 0x44fa0000) (generic, 0x80090000, 0x2e)`
+
+	expected := []FlowSpecRoute{
+		{
+			Route: Route{
+				Network:      "flow4 { dst 192.0.2.0/24; proto 6; dport 80,443; sport 1024..65535; tcp flags 0x2/0x12; fragment !is_fragment; }",
+				FromProtocol: "flow_rr",
+				Since:        "2026-01-16",
+				Primary:      true,
+				Metric:       100,
+				Type:         []string{"BGP", "univ"},
+				BGP: &RouteBGPInfo{
+					Origin:    "IGP",
+					ASPath:    []int{64500},
+					LocalPref: 100,
+				},
+				Attributes: []RouteAttribute{
+					{Name: "Type", Value: "BGP univ"},
+					{Name: "BGP.origin", Value: "IGP"},
+					{Name: "BGP.as_path", Value: "64500"},
+					{Name: "BGP.local_pref", Value: "100"},
+					{Name: "BGP.ext_community", Value: "(generic, 0x80060000, 0x0) (generic, 0x82080000, 0xfde80064)"},
+				},
+			},
+			Flow: FlowSpec{
+				Family:      "flow4",
+				Destination: "192.0.2.0/24",
+				Protocol: []FlowSpecNumericMatch{
+					{Operator: "=", Value: 6},
+				},
+				DestinationPort: []FlowSpecNumericMatch{
+					{Operator: "=", Value: 80},
+					{Operator: "=", Value: 443},
+				},
+				SourcePort: []FlowSpecNumericMatch{
+					{Operator: ">=", Value: 1024},
+					{And: true, Operator: "<=", Value: 65535},
+				},
+				TCPFlags: []FlowSpecBitmaskMatch{
+					{Value: 0x2, Mask: 0x12},
+				},
+				Fragment: []FlowSpecBitmaskMatch{
+					{Not: true, Value: 2, Mask: 2},
+				},
+			},
+			Actions: []FlowSpecAction{
+				{Type: "traffic-rate"},
+				{Type: "redirect", ASN: 65000, Target: "65000:100"},
+			},
+		},
+		{
+			Route: Route{
+				Network:      "flow4 { dst 198.51.100.7/32; proto 17; length > 1000 && < 1500; }",
+				FromProtocol: "flow_rr",
+				Since:        "2026-01-16",
+				Primary:      true,
+				Metric:       100,
+				Type:         []string{"BGP", "univ"},
+				Attributes: []RouteAttribute{
+					{Name: "Type", Value: "BGP univ"},
+					{Name: "BGP.ext_community", Value: "(generic, 0x8006fc00, 0x44fa0000) (generic, 0x80090000, 0x2e)"},
+				},
+			},
+			Flow: FlowSpec{
+				Family:      "flow4",
+				Destination: "198.51.100.7/32",
+				Protocol: []FlowSpecNumericMatch{
+					{Operator: "=", Value: 17},
+				},
+				Length: []FlowSpecNumericMatch{
+					{Operator: ">", Value: 1000},
+					{And: true, Operator: "<", Value: 1500},
+				},
+			},
+			Actions: []FlowSpecAction{
+				{Type: "traffic-rate", ASN: 64512, Rate: 2000},
+				{Type: "traffic-marking", DSCP: 46},
+			},
+		},
+	}
+
+	result := ParseFlowSpecRoutes(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("ParseFlowSpecRoutes() = %+v, want %+v", result, expected)
+	}
+
+	if !result[0].IsDiscard() || result[1].IsDiscard() {
+		t.Errorf("IsDiscard() mismatch")
+	}
+}
+
+func TestParseFlowSpec(t *testing.T) {
+	result := ParseFlowSpec("flow6 { dst 2001:db8::/32 offset 8; next header 58; icmp type 128; icmp code 0; label 0x1/0xff; }")
+
+	expected := FlowSpec{
+		Family:            "flow6",
+		Destination:       "2001:db8::/32",
+		DestinationOffset: 8,
+		Protocol:          []FlowSpecNumericMatch{{Operator: "=", Value: 58}},
+		ICMPType:          []FlowSpecNumericMatch{{Operator: "=", Value: 128}},
+		ICMPCode:          []FlowSpecNumericMatch{{Operator: "=", Value: 0}},
+		Label:             []FlowSpecBitmaskMatch{{Value: 1, Mask: 0xff}},
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseFlowSpec() = %+v, want %+v", result, expected)
+	}
+}
@@ -0,0 +1,146 @@
+package birdtest
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type Server struct {
+	Network string
+	Address string
+
+	listener net.Listener
+	mu       sync.Mutex
+	replies  map[string][]string
+	queries  []string
+	wg       sync.WaitGroup
+}
+
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	address := filepath.Join(t.TempDir(), "bird.ctl")
+	listener, err := net.Listen("unix", address)
+	if err != nil {
+		t.Fatalf("listen %s: %v", address, err)
+	}
+
+	s := &Server{
+		Network:  "unix",
+		Address:  address,
+		listener: listener,
+		replies:  make(map[string][]string),
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *Server) Handle(command string, output ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, o := range output {
+		s.replies[command] = append(s.replies[command], encodeReply(o))
+	}
+}
+
+func (s *Server) HandleError(command string, code int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.replies[command] = append(s.replies[command], fmt.Sprintf("%04d %s\n", code, message))
+}
+
+func (s *Server) Queries() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.queries...)
+}
+
+func (s *Server) Close() {
+	s.listener.Close()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.wg.Add(1)
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer s.wg.Done()
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("0001 BIRD 2.17.1 ready.\n")); err != nil {
+		return
+	}
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		command := strings.TrimSpace(scanner.Text())
+
+		if _, err := conn.Write([]byte(s.reply(command))); err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) reply(command string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.queries = append(s.queries, command)
+
+	replies, ok := s.replies[command]
+	if !ok || len(replies) == 0 {
+		return "9001 syntax error, unexpected CF_SYM_UNDEFINED\n"
+	}
+
+	if len(replies) > 1 {
+		s.replies[command] = replies[1:]
+	}
+
+	return replies[0]
+}
+
+func encodeReply(output string) string {
+	var b strings.Builder
+
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		if strings.HasPrefix(line, "BIRD ") && strings.HasSuffix(line, " ready.") {
+			continue
+		}
+
+		if b.Len() == 0 {
+			b.WriteString("1000-")
+		} else {
+			b.WriteString(" ")
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("0000 \n")
+
+	return b.String()
+}
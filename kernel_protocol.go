@@ -0,0 +1,207 @@
+package birdparse
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+func ParseKernelProtocol(data string) KernelProtocol {
+	result := KernelProtocol{}
+	var channel *ProtocolChannel
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if m := matchProtocolHeader(line, "Kernel"); m != nil {
+			result.Protocol = m[1]
+			result.Table = m[2]
+			result.State = m[3]
+
+			result.Connection = strings.TrimSpace(m[5])
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Description:\s+(.*)$`).FindStringSubmatch(line); m != nil {
+			result.Description = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Channel\s+(\S+)$`).FindStringSubmatch(line); m != nil {
+			channel = &ProtocolChannel{Name: m[1]}
+			continue
+		}
+
+		if channel != nil {
+			applyChannelLine(channel, line)
+		}
+	}
+
+	if channel != nil {
+		result.Channel = channel.Name
+		if channel.Table != "" {
+			result.Table = channel.Table
+		}
+		result.Preference = channel.Preference
+		result.InputFilter = channel.InputFilter
+		result.OutputFilter = channel.OutputFilter
+		result.Routes = channel.Routes
+		result.RouteChanges = channel.RouteChanges
+	}
+
+	return result
+}
+
+func ParseKernelProtocols(data string) []KernelProtocol {
+	var results []KernelProtocol
+
+	for _, block := range splitProtocolBlocks(data, "Kernel") {
+		p := ParseKernelProtocol(block)
+		if p.IsValid() {
+			results = append(results, p)
+		}
+	}
+
+	return results
+}
+
+func ParseKernelConfig(conf string) []KernelProtocol {
+	var results []KernelProtocol
+	templates := make(map[string]KernelProtocol)
+	used := make(map[string]bool)
+	counter := 0
+
+	var (
+		words    []string
+		current  *KernelProtocol
+		template string
+		depth    int
+	)
+
+	for _, tok := range tokenizeConfig(conf) {
+		switch tok {
+		case "{":
+			if depth == 0 {
+				current, template = startKernelBlock(words, templates, used, &counter)
+			}
+			depth++
+			words = nil
+		case "}":
+			depth--
+			if depth == 0 && current != nil {
+				if template != "" {
+					templates[template] = *current
+				} else {
+					results = append(results, *current)
+				}
+				current = nil
+			}
+			words = nil
+		case ";":
+			if depth == 1 && current != nil {
+				applyKernelConfigStatement(current, words)
+			}
+			words = nil
+		default:
+			words = append(words, tok)
+		}
+	}
+
+	return results
+}
+
+func startKernelBlock(words []string, templates map[string]KernelProtocol, used map[string]bool, counter *int) (*KernelProtocol, string) {
+	if len(words) < 2 || words[1] != "kernel" {
+		return nil, ""
+	}
+
+	var name, from string
+	rest := words[2:]
+	if len(rest) > 0 && rest[0] != "from" {
+		name, rest = rest[0], rest[1:]
+	}
+	if len(rest) == 2 && rest[0] == "from" {
+		from = rest[1]
+	}
+
+	result := templates[from]
+
+	switch words[0] {
+	case "template":
+		return &result, name
+	case "protocol":
+		for name == "" {
+			*counter++
+			if candidate := fmt.Sprintf("kernel%d", *counter); !used[candidate] {
+				name = candidate
+			}
+		}
+		used[name] = true
+		result.Protocol = name
+		return &result, ""
+	}
+
+	return nil, ""
+}
+
+func applyKernelConfigStatement(p *KernelProtocol, words []string) {
+	if len(words) == 0 {
+		return
+	}
+
+	enabled := len(words) == 1 || words[1] == "yes" || words[1] == "on" || words[1] == "all"
+
+	switch {
+	case len(words) == 3 && words[0] == "kernel" && words[1] == "table":
+		p.KernelTable = atoi(words[2])
+	case len(words) == 3 && words[0] == "scan" && words[1] == "time":
+		p.ScanTime = atoi(words[2])
+	case words[0] == "learn":
+		p.Learn = enabled
+	case words[0] == "persist":
+		p.Persist = enabled
+	}
+}
+
+func tokenizeConfig(conf string) []string {
+	var tokens []string
+
+	for i := 0; i < len(conf); {
+		c := conf[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '#':
+			for i < len(conf) && conf[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(conf[i:], "/*"):
+			end := strings.Index(conf[i+2:], "*/")
+			if end < 0 {
+				return tokens
+			}
+			i += end + 4
+		case c == '{' || c == '}' || c == ';':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"':
+			end := strings.IndexByte(conf[i+1:], '"')
+			if end < 0 {
+				return tokens
+			}
+			tokens = append(tokens, conf[i:i+end+2])
+			i += end + 2
+		default:
+			start := i
+			for i < len(conf) && !strings.ContainsRune(" \t\r\n{};#\"", rune(conf[i])) {
+				i++
+			}
+			tokens = append(tokens, conf[start:i])
+		}
+	}
+
+	return tokens
+}
@@ -0,0 +1,62 @@
+package birdparse
+
+type KernelProtocol struct {
+	Protocol     string                   `json:"protocol"`
+	Table        string                   `json:"table"`
+	State        string                   `json:"state"`
+	Connection   string                   `json:"connection"`
+	Description  string                   `json:"description"`
+	Channel      string                   `json:"channel"`
+	Preference   int                      `json:"preference"`
+	InputFilter  string                   `json:"input_filter"`
+	OutputFilter string                   `json:"output_filter"`
+	Routes       *BgpProtocolBgpRoutes    `json:"routes"`
+	RouteChanges *BgpProtocolRouteChanges `json:"route_changes"`
+	KernelTable  int                      `json:"kernel_table"`
+	Learn        bool                     `json:"learn"`
+	Persist      bool                     `json:"persist"`
+	ScanTime     int                      `json:"scan_time"`
+}
+
+func (p KernelProtocol) IsValid() bool {
+	if p.Protocol == "" &&
+		p.Table == "" {
+		return false
+	}
+
+	return true
+}
+
+func (p KernelProtocol) ExportStalled(previous KernelProtocol) bool {
+	if p.RouteChanges == nil || previous.RouteChanges == nil {
+		return false
+	}
+
+	received := func(d *BgpProtocolRouteChangeDetail) int {
+		if d == nil {
+			return 0
+		}
+		return atoi(d.Received)
+	}
+
+	accepted := func(d *BgpProtocolRouteChangeDetail) int {
+		if d == nil {
+			return 0
+		}
+		return atoi(d.Accepted)
+	}
+
+	offered := received(p.RouteChanges.ExportUpdates) + received(p.RouteChanges.ExportWithdraws) -
+		received(previous.RouteChanges.ExportUpdates) - received(previous.RouteChanges.ExportWithdraws)
+	written := accepted(p.RouteChanges.ExportUpdates) + accepted(p.RouteChanges.ExportWithdraws) -
+		accepted(previous.RouteChanges.ExportUpdates) - accepted(previous.RouteChanges.ExportWithdraws)
+
+	return offered > 0 && written == 0
+}
+
+func (p *KernelProtocol) MergeConfig(config KernelProtocol) {
+	p.KernelTable = config.KernelTable
+	p.Learn = config.Learn
+	p.Persist = config.Persist
+	p.ScanTime = config.ScanTime
+}
@@ -0,0 +1,148 @@
+package birdparse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKernelProtocols(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+Access restricted
+kernel4    Kernel     master4    up     2026-01-16
+  Channel ipv4
+    State:          UP
+    Table:          master4
+    Preference:     10
+    Input filter:   ACCEPT
+    Output filter:  kernel_export
+    Routes:         2 imported, 1032 exported, 2 preferred
+    Route change stats:     received   rejected   filtered    ignored   accepted
+      Import updates:              2          0          0          0          2
+      Import withdraws:            0          0        ---          0          0
+      Export updates:           4410          0         12        ---       4398
+      Export withdraws:         3366        ---        ---        ---       3366
+device1    Device     ---        up     2026-01-16
+kernel6    Kernel     master6    start  2026-01-16`
+
+	expected := []KernelProtocol{
+		{
+			Protocol:     "kernel4",
+			Table:        "master4",
+			State:        "up",
+			Channel:      "ipv4",
+			Preference:   10,
+			InputFilter:  "ACCEPT",
+			OutputFilter: "kernel_export",
+			Routes: &BgpProtocolBgpRoutes{
+				Imported:  "2",
+				Exported:  "1032",
+				Preferred: "2",
+			},
+			RouteChanges: &BgpProtocolRouteChanges{
+				ImportUpdates: &BgpProtocolRouteChangeDetail{
+					Received: "2",
+					Rejected: "0",
+					Filtered: "0",
+					Ignored:  "0",
+					Accepted: "2",
+				},
+				ImportWithdraws: &BgpProtocolRouteChangeDetail{
+					Received: "0",
+					Rejected: "0",
+					Filtered: "0",
+					Ignored:  "0",
+					Accepted: "0",
+				},
+				ExportUpdates: &BgpProtocolRouteChangeDetail{
+					Received: "4410",
+					Rejected: "0",
+					Filtered: "12",
+					Ignored:  "0",
+					Accepted: "4398",
+				},
+				ExportWithdraws: &BgpProtocolRouteChangeDetail{
+					Received: "3366",
+					Rejected: "0",
+					Filtered: "0",
+					Ignored:  "0",
+					Accepted: "3366",
+				},
+			},
+		},
+		{
+			Protocol: "kernel6",
+			Table:    "master6",
+			State:    "start",
+		},
+	}
+
+	result := ParseKernelProtocols(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("ParseKernelProtocols() = %+v, want %+v", result, expected)
+	}
+
+	previous := result[0]
+	current := result[0]
+	current.RouteChanges = &BgpProtocolRouteChanges{
+		ExportUpdates: &BgpProtocolRouteChangeDetail{
+			Received: "4500",
+			Accepted: "4398",
+		},
+		ExportWithdraws: previous.RouteChanges.ExportWithdraws,
+	}
+
+	if !current.ExportStalled(previous) {
+		t.Errorf("ExportStalled() = false, want true")
+	}
+
+	if previous.ExportStalled(previous) {
+		t.Errorf("ExportStalled() = true, want false")
+	}
+}
+
+func TestParseKernelConfig(t *testing.T) {
+	conf := `router id 192.0.2.1;
+
+template kernel sync {
+  scan time 20;   # seconds
+  persist;
+}
+
+protocol kernel kernel4 from sync {
+  ipv4 {
+    export filter kernel_export;
+  };
+  kernel table 100;
+  learn;
+}
+
+/* IPv6 routes go to the main table */
+protocol kernel {
+  ipv6 { export all; };
+  persist off;
+  scan time 60;
+}
+
+protocol device {
+  scan time 10;
+}`
+
+	expected := []KernelProtocol{
+		{Protocol: "kernel4", KernelTable: 100, Learn: true, Persist: true, ScanTime: 20},
+		{Protocol: "kernel1", ScanTime: 60},
+	}
+
+	result := ParseKernelConfig(conf)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("ParseKernelConfig() = %+v, want %+v", result, expected)
+	}
+
+	protocol := KernelProtocol{Protocol: "kernel4", State: "up"}
+	protocol.MergeConfig(result[0])
+
+	if protocol.KernelTable != 100 || !protocol.Learn || !protocol.Persist || protocol.ScanTime != 20 || protocol.State != "up" {
+		t.Errorf("MergeConfig() = %+v", protocol)
+	}
+}
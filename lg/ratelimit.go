@@ -0,0 +1,96 @@
+package lg
+
+import (
+	"container/list"
+	"net/netip"
+	"sync"
+	"time"
+)
+
+const maxRateLimitBuckets = 4096
+
+type rateLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[string]*list.Element
+	recent  *list.List
+	max     int
+	now     func() time.Time
+}
+
+type bucket struct {
+	key    string
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*list.Element),
+		recent:  list.New(),
+		max:     maxRateLimitBuckets,
+		now:     time.Now,
+	}
+}
+
+func (l *rateLimiter) allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	var b *bucket
+	if elem, ok := l.buckets[key]; ok {
+		b = elem.Value.(*bucket)
+		l.recent.MoveToFront(elem)
+	} else {
+		b = &bucket{key: key, tokens: l.burst, last: now}
+		l.buckets[key] = l.recent.PushFront(b)
+	}
+
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	l.evict(now)
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+func (l *rateLimiter) evict(now time.Time) {
+	for elem := l.recent.Back(); elem != nil && elem != l.recent.Front(); elem = l.recent.Back() {
+		b := elem.Value.(*bucket)
+		if len(l.buckets) <= l.max && now.Sub(b.last).Seconds()*l.rate < l.burst {
+			return
+		}
+
+		l.recent.Remove(elem)
+		delete(l.buckets, b.key)
+	}
+}
+
+func rateLimitKey(host string) string {
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return host
+	}
+
+	addr = addr.Unmap().WithZone("")
+	if addr.Is4() {
+		return addr.String()
+	}
+
+	prefix, _ := addr.Prefix(64)
+	return prefix.String()
+}
@@ -0,0 +1,260 @@
+package lg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LaunchPad-Network/birdparse"
+)
+
+var (
+	protocolNameRE = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
+	communityRE    = regexp.MustCompile(`^(\d+):(\d+)(?::(\d+))?$`)
+)
+
+type Server struct {
+	Network   string
+	Address   string
+	Timeout   time.Duration
+	Allowed   []string
+	RateLimit float64
+	Burst     int
+
+	once    sync.Once
+	mux     *http.ServeMux
+	limiter *rateLimiter
+}
+
+type httpError struct {
+	status  int
+	message string
+}
+
+func (e *httpError) Error() string {
+	return e.message
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.once.Do(s.init)
+
+	if s.limiter != nil && !s.limiter.allow(rateLimitKey(clientIP(r))) {
+		writeError(w, &httpError{http.StatusTooManyRequests, "rate limit exceeded"})
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) init() {
+	s.mux = http.NewServeMux()
+
+	if s.RateLimit > 0 {
+		s.limiter = newRateLimiter(s.RateLimit, s.Burst)
+	}
+
+	s.handle("GET /protocols", "protocols", s.protocols)
+	s.handle("GET /protocols/{name}", "protocol", s.protocol)
+	s.handle("GET /routes/prefix/{prefix...}", "prefix", s.routesForPrefix)
+	s.handle("GET /routes/community/{community}", "community", s.routesForCommunity)
+	s.handle("GET /routes/aspath/{asn}", "aspath", s.routesForASPath)
+	s.handle("GET /routes/protocol/{name}", "protocol_routes", s.routesFromProtocol)
+}
+
+func (s *Server) handle(pattern, name string, fn func(r *http.Request) (any, error)) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if s.Allowed != nil && !slices.Contains(s.Allowed, name) {
+			writeError(w, &httpError{http.StatusForbidden, "query not allowed"})
+			return
+		}
+
+		result, err := fn(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, result)
+	})
+}
+
+func (s *Server) protocols(r *http.Request) (any, error) {
+	output, err := s.query(r.Context(), "show protocols all")
+	if err != nil {
+		return nil, err
+	}
+
+	return nonNil(birdparse.ParseBGPProtocols(output)), nil
+}
+
+func (s *Server) protocol(r *http.Request) (any, error) {
+	name, err := protocolName(r.PathValue("name"))
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := s.query(r.Context(), "show protocols all "+name)
+	if err != nil {
+		return nil, err
+	}
+
+	protocols := birdparse.ParseBGPProtocols(output)
+	if len(protocols) == 0 {
+		return nil, &httpError{http.StatusNotFound, "no such BGP protocol"}
+	}
+
+	return protocols[0], nil
+}
+
+func (s *Server) routesForPrefix(r *http.Request) (any, error) {
+	value := r.PathValue("prefix")
+
+	var target string
+	if prefix, err := netip.ParsePrefix(value); err == nil {
+		target = prefix.String()
+	} else if addr, err := netip.ParseAddr(value); err == nil {
+		target = addr.WithZone("").String()
+	} else {
+		return nil, &httpError{http.StatusBadRequest, "invalid prefix or address"}
+	}
+
+	return s.routes(r.Context(), "show route all for "+target)
+}
+
+func (s *Server) routesForCommunity(r *http.Request) (any, error) {
+	m := communityRE.FindStringSubmatch(r.PathValue("community"))
+	if m == nil {
+		return nil, &httpError{http.StatusBadRequest, "invalid community"}
+	}
+
+	if m[3] == "" {
+		global, err1 := strconv.ParseUint(m[1], 10, 16)
+		local, err2 := strconv.ParseUint(m[2], 10, 16)
+		if err1 != nil || err2 != nil {
+			return nil, &httpError{http.StatusBadRequest, "invalid community"}
+		}
+
+		return s.routes(r.Context(), fmt.Sprintf("show route all where (%d,%d) ~ bgp_community", global, local))
+	}
+
+	parts := make([]uint64, 3)
+	for i := range parts {
+		v, err := strconv.ParseUint(m[i+1], 10, 32)
+		if err != nil {
+			return nil, &httpError{http.StatusBadRequest, "invalid large community"}
+		}
+		parts[i] = v
+	}
+
+	return s.routes(r.Context(), fmt.Sprintf("show route all where (%d,%d,%d) ~ bgp_large_community", parts[0], parts[1], parts[2]))
+}
+
+func (s *Server) routesForASPath(r *http.Request) (any, error) {
+	asn, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(r.PathValue("asn")), "AS"), 10, 32)
+	if err != nil {
+		return nil, &httpError{http.StatusBadRequest, "invalid AS number"}
+	}
+
+	return s.routes(r.Context(), fmt.Sprintf("show route all where bgp_path ~ [= * %d * =]", asn))
+}
+
+func (s *Server) routesFromProtocol(r *http.Request) (any, error) {
+	name, err := protocolName(r.PathValue("name"))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.routes(r.Context(), "show route all protocol "+name)
+}
+
+func (s *Server) routes(ctx context.Context, command string) (any, error) {
+	output, err := s.query(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	return nonNil(birdparse.ParseRoutes(output)), nil
+}
+
+func (s *Server) query(ctx context.Context, command string) (string, error) {
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	network := s.Network
+	if network == "" {
+		network = "unix"
+	}
+
+	client, err := birdparse.Dial(ctx, network, s.Address)
+	if err != nil {
+		return "", &httpError{http.StatusBadGateway, "bird unavailable"}
+	}
+	defer client.Close()
+
+	output, err := client.Query(ctx, command)
+
+	var birdErr *birdparse.BirdError
+	switch {
+	case errors.As(err, &birdErr) && birdErr.Code == 8003:
+		return "", &httpError{http.StatusNotFound, birdErr.Message}
+	case errors.As(err, &birdErr) && birdErr.Code >= 9000:
+		return "", &httpError{http.StatusBadRequest, birdErr.Message}
+	case err != nil:
+		return "", &httpError{http.StatusBadGateway, "bird query failed"}
+	}
+
+	return output, nil
+}
+
+func protocolName(name string) (string, error) {
+	if !protocolNameRE.MatchString(name) {
+		return "", &httpError{http.StatusBadRequest, "invalid protocol name"}
+	}
+	return name, nil
+}
+
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var httpErr *httpError
+	if errors.As(err, &httpErr) {
+		status = httpErr.status
+	}
+
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,241 @@
+package lg
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/LaunchPad-Network/birdparse"
+	"github.com/LaunchPad-Network/birdparse/internal/birdtest"
+)
+
+const testProtocols = `BIRD 2.17.1 ready.
+peer_a     BGP        ---        up     2026-01-16    Established
+  BGP state:          Established
+    Neighbor address: 192.0.2.1
+    Neighbor AS:      64500
+peer_b     BGP        ---        start  2026-01-16    Active
+  BGP state:          Active
+    Neighbor address: 192.0.2.2
+    Neighbor AS:      64501`
+
+const testRoutes = `Table master4:
+192.0.2.0/24         unicast [peer_a 2026-01-16] * (100) [AS64500i]
+        via 192.0.2.1 on eth0
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 64500
+        BGP.next_hop: 192.0.2.1
+        BGP.local_pref: 100
+        BGP.community: (64500,1)`
+
+func newTestServer(t *testing.T) (*Server, *birdtest.Server) {
+	bird := birdtest.NewServer(t)
+	bird.Handle("show protocols all", testProtocols)
+	bird.Handle("show protocols all peer_a", testProtocols)
+	bird.HandleError("show protocols all peer_x", 8003, "No protocols match")
+	bird.Handle("show route all for 192.0.2.0/24", testRoutes)
+	bird.Handle("show route all for 192.0.2.1", testRoutes)
+	bird.Handle("show route all where (64500,1) ~ bgp_community", testRoutes)
+	bird.Handle("show route all where (64500,1,2) ~ bgp_large_community", "")
+	bird.Handle("show route all where bgp_path ~ [= * 64500 * =]", testRoutes)
+	bird.Handle("show route all protocol peer_a", testRoutes)
+
+	return &Server{Network: bird.Network, Address: bird.Address, Timeout: time.Second}, bird
+}
+
+func get(t *testing.T, handler http.Handler, path string, result any) int {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", path, nil))
+
+	if result != nil && recorder.Code == http.StatusOK {
+		if err := json.Unmarshal(recorder.Body.Bytes(), result); err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+	}
+
+	return recorder.Code
+}
+
+func TestServerProtocols(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	var protocols []birdparse.BgpProtocol
+	if code := get(t, server, "/protocols", &protocols); code != http.StatusOK {
+		t.Fatalf("GET /protocols = %d", code)
+	}
+
+	if len(protocols) != 2 || protocols[0].Protocol != "peer_a" || protocols[1].BgpState != "Active" {
+		t.Errorf("GET /protocols = %+v", protocols)
+	}
+
+	var protocol birdparse.BgpProtocol
+	if code := get(t, server, "/protocols/peer_a", &protocol); code != http.StatusOK || protocol.NeighborAS != 64500 {
+		t.Errorf("GET /protocols/peer_a = %d %+v", code, protocol)
+	}
+
+	if code := get(t, server, "/protocols/peer_x", nil); code != http.StatusNotFound {
+		t.Errorf("GET /protocols/peer_x = %d, want 404", code)
+	}
+
+	if code := get(t, server, "/protocols/peer_a;configure", nil); code != http.StatusBadRequest {
+		t.Errorf("GET /protocols/peer_a;configure = %d, want 400", code)
+	}
+}
+
+func TestServerRoutes(t *testing.T) {
+	server, bird := newTestServer(t)
+
+	paths := []string{
+		"/routes/prefix/192.0.2.0/24",
+		"/routes/prefix/192.0.2.1",
+		"/routes/community/64500:1",
+		"/routes/aspath/AS64500",
+		"/routes/protocol/peer_a",
+	}
+
+	for _, path := range paths {
+		var routes []birdparse.Route
+		if code := get(t, server, path, &routes); code != http.StatusOK {
+			t.Fatalf("GET %s = %d", path, code)
+		}
+
+		if len(routes) != 1 || routes[0].Network != "192.0.2.0/24" || routes[0].BGP.LocalPref != 100 {
+			t.Errorf("GET %s = %+v", path, routes)
+		}
+	}
+
+	var routes []birdparse.Route
+	if code := get(t, server, "/routes/community/64500:1:2", &routes); code != http.StatusOK || routes == nil || len(routes) != 0 {
+		t.Errorf("GET /routes/community/64500:1:2 = %d %v, want empty list", code, routes)
+	}
+
+	invalid := []string{
+		"/routes/prefix/192.0.2.0%2F24%20all",
+		"/routes/community/70000:1",
+		"/routes/aspath/64500x",
+		"/routes/protocol/peer%20a",
+	}
+
+	for _, path := range invalid {
+		if code := get(t, server, path, nil); code != http.StatusBadRequest {
+			t.Errorf("GET %s = %d, want 400", path, code)
+		}
+	}
+
+	expected := []string{
+		"show route all for 192.0.2.0/24",
+		"show route all for 192.0.2.1",
+		"show route all where (64500,1) ~ bgp_community",
+		"show route all where bgp_path ~ [= * 64500 * =]",
+		"show route all protocol peer_a",
+		"show route all where (64500,1,2) ~ bgp_large_community",
+	}
+
+	if queries := bird.Queries(); !reflect.DeepEqual(queries, expected) {
+		t.Errorf("queries = %q, want %q", queries, expected)
+	}
+}
+
+func TestServerAllowed(t *testing.T) {
+	server, _ := newTestServer(t)
+	server.Allowed = []string{"protocols"}
+
+	if code := get(t, server, "/protocols", nil); code != http.StatusOK {
+		t.Errorf("GET /protocols = %d, want 200", code)
+	}
+
+	if code := get(t, server, "/routes/protocol/peer_a", nil); code != http.StatusForbidden {
+		t.Errorf("GET /routes/protocol/peer_a = %d, want 403", code)
+	}
+}
+
+func TestServerRateLimit(t *testing.T) {
+	server, _ := newTestServer(t)
+	server.RateLimit = 1
+	server.Burst = 2
+
+	codes := []int{
+		get(t, server, "/protocols", nil),
+		get(t, server, "/protocols", nil),
+		get(t, server, "/protocols", nil),
+	}
+
+	if !reflect.DeepEqual(codes, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}) {
+		t.Errorf("status codes = %v", codes)
+	}
+}
+
+func TestRateLimiterRefill(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newRateLimiter(2, 1)
+	limiter.now = func() time.Time { return now }
+
+	if !limiter.allow("a") || limiter.allow("a") {
+		t.Fatalf("burst of 1 not enforced")
+	}
+
+	if !limiter.allow("b") {
+		t.Errorf("separate client was limited")
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if !limiter.allow("a") {
+		t.Errorf("bucket did not refill")
+	}
+}
+
+func TestServerRateLimitIPv6Prefix(t *testing.T) {
+	server, _ := newTestServer(t)
+	server.RateLimit = 1
+	server.Burst = 1
+
+	request := func(remote string) int {
+		r := httptest.NewRequest("GET", "/protocols", nil)
+		r.RemoteAddr = remote
+
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, r)
+		return recorder.Code
+	}
+
+	codes := []int{
+		request("[2001:db8:1:2::1]:40000"),
+		request("[2001:db8:1:2:ffff::99]:40001"),
+		request("[2001:db8:1:3::1]:40002"),
+		request("192.0.2.10:40003"),
+		request("192.0.2.11:40004"),
+	}
+
+	expected := []int{http.StatusOK, http.StatusTooManyRequests, http.StatusOK, http.StatusOK, http.StatusOK}
+	if !reflect.DeepEqual(codes, expected) {
+		t.Errorf("status codes = %v, want %v", codes, expected)
+	}
+}
+
+func TestRateLimiterEviction(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newRateLimiter(1, 1)
+	limiter.max = 2
+	limiter.now = func() time.Time { return now }
+
+	limiter.allow("a")
+	limiter.allow("b")
+	limiter.allow("c")
+
+	if _, ok := limiter.buckets["a"]; ok || len(limiter.buckets) != 2 {
+		t.Errorf("buckets = %v, want least recently used evicted", limiter.buckets)
+	}
+
+	now = now.Add(2 * time.Second)
+	limiter.allow("d")
+
+	if len(limiter.buckets) != 1 {
+		t.Errorf("len(buckets) = %d, want expired buckets evicted", len(limiter.buckets))
+	}
+}
@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/LaunchPad-Network/birdparse"
+)
+
+var bgpStates = []string{
+	"Idle",
+	"Connect",
+	"Active",
+	"OpenSent",
+	"OpenConfirm",
+	"Established",
+	"Close",
+	"Passive",
+}
+
+func BgpFamilies(protocols []birdparse.BgpProtocol) []Family {
+	up := Family{Name: "bird_bgp_session_up", Help: "Whether the BGP session is established.", Type: "gauge"}
+	info := Family{Name: "bird_bgp_session_info", Help: "Static information about the BGP session.", Type: "gauge"}
+	state := Family{Name: "bird_bgp_state", Help: "Current BGP state of the session.", Type: "gauge"}
+	holdTimer := Family{Name: "bird_bgp_hold_timer_seconds", Help: "Negotiated hold timer.", Type: "gauge"}
+	holdTimerNow := Family{Name: "bird_bgp_hold_timer_remaining_seconds", Help: "Time left on the hold timer.", Type: "gauge"}
+	keepalive := Family{Name: "bird_bgp_keepalive_seconds", Help: "Negotiated keepalive timer.", Type: "gauge"}
+	keepaliveNow := Family{Name: "bird_bgp_keepalive_remaining_seconds", Help: "Time left until the next keepalive.", Type: "gauge"}
+	routes := Family{Name: "bird_bgp_routes", Help: "Number of routes in the channel by type.", Type: "gauge"}
+	changes := Family{Name: "bird_bgp_route_changes", Help: "Route change statistics by direction, kind and result.", Type: "counter"}
+
+	protocols = append([]birdparse.BgpProtocol(nil), protocols...)
+	sort.SliceStable(protocols, func(i, j int) bool {
+		return protocols[i].Protocol < protocols[j].Protocol
+	})
+
+	for _, p := range protocols {
+		protocol := Label{"protocol", p.Protocol}
+		neighborAS := Label{"neighbor_as", strconv.Itoa(p.NeighborAS)}
+
+		up.add(boolValue(p.BgpState == "Established"), protocol, neighborAS)
+
+		info.add(1, protocol, neighborAS,
+			Label{"neighbor_address", p.NeighborAddress},
+			Label{"neighbor_id", p.NeighborID},
+			Label{"description", p.Description},
+		)
+
+		known := false
+		for _, s := range bgpStates {
+			known = known || s == p.BgpState
+			state.add(boolValue(s == p.BgpState), protocol, neighborAS, Label{"state", s})
+		}
+		if !known && p.BgpState != "" {
+			state.add(1, protocol, neighborAS, Label{"state", p.BgpState})
+		}
+
+		if p.BgpState == "Established" {
+			holdTimer.add(float64(p.HoldTimer), protocol, neighborAS)
+			holdTimerNow.add(float64(p.HoldTimerNow), protocol, neighborAS)
+			keepalive.add(float64(p.Keepalive), protocol, neighborAS)
+			keepaliveNow.add(float64(p.KeepaliveNow), protocol, neighborAS)
+		}
+
+		if p.Routes != nil {
+			counts := []struct {
+				kind  string
+				value string
+			}{
+				{"imported", p.Routes.Imported},
+				{"filtered", p.Routes.Filtered},
+				{"exported", p.Routes.Exported},
+				{"preferred", p.Routes.Preferred},
+			}
+
+			for _, count := range counts {
+				if v, err := strconv.Atoi(count.value); err == nil {
+					routes.add(float64(v), protocol, neighborAS, Label{"type", count.kind})
+				}
+			}
+		}
+
+		if p.RouteChanges != nil {
+			details := []struct {
+				direction string
+				kind      string
+				detail    *birdparse.BgpProtocolRouteChangeDetail
+			}{
+				{"import", "updates", p.RouteChanges.ImportUpdates},
+				{"import", "withdraws", p.RouteChanges.ImportWithdraws},
+				{"export", "updates", p.RouteChanges.ExportUpdates},
+				{"export", "withdraws", p.RouteChanges.ExportWithdraws},
+			}
+
+			for _, d := range details {
+				if d.detail == nil {
+					continue
+				}
+
+				results := []struct {
+					name  string
+					value string
+				}{
+					{"received", d.detail.Received},
+					{"rejected", d.detail.Rejected},
+					{"filtered", d.detail.Filtered},
+					{"ignored", d.detail.Ignored},
+					{"accepted", d.detail.Accepted},
+				}
+
+				for _, r := range results {
+					if v, err := strconv.Atoi(r.value); err == nil {
+						changes.add(float64(v), protocol, neighborAS,
+							Label{"direction", d.direction},
+							Label{"kind", d.kind},
+							Label{"result", r.name},
+						)
+					}
+				}
+			}
+		}
+	}
+
+	return []Family{up, info, state, holdTimer, holdTimerNow, keepalive, keepaliveNow, routes, changes}
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
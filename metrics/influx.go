@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func WriteInflux(w io.Writer, families []Family, timestamp time.Time) error {
+	bw := bufio.NewWriter(w)
+
+	for _, family := range families {
+		for _, sample := range family.Samples {
+			bw.WriteString(escapeInfluxMeasurement(family.Name))
+
+			labels := append([]Label(nil), sample.Labels...)
+			sort.SliceStable(labels, func(i, j int) bool {
+				return labels[i].Name < labels[j].Name
+			})
+
+			for _, label := range labels {
+				if label.Value == "" {
+					continue
+				}
+				bw.WriteString("," + escapeInfluxTag(label.Name) + "=" + escapeInfluxTag(label.Value))
+			}
+
+			bw.WriteString(" value=" + formatInfluxValue(family.Type, sample.Value))
+
+			if !timestamp.IsZero() {
+				bw.WriteString(" " + strconv.FormatInt(timestamp.UnixNano(), 10))
+			}
+
+			bw.WriteString("\n")
+		}
+	}
+
+	return bw.Flush()
+}
+
+func formatInfluxValue(kind string, v float64) string {
+	if kind == "counter" && v == math.Trunc(v) {
+		return strconv.FormatInt(int64(v), 10) + "i"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func escapeInfluxMeasurement(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ",", `\,`, " ", `\ `, "\n", `\n`).Replace(s)
+}
+
+func escapeInfluxTag(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ",", `\,`, "=", `\=`, " ", `\ `, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestWriteInflux(t *testing.T) {
+	families := []Family{
+		{
+			Name: "bird_bgp_session_info",
+			Type: "gauge",
+			Samples: []Sample{
+				{Labels: []Label{{"protocol", "peer_a"}, {"description", `Transit, "A"=1`}, {"neighbor_id", ""}}, Value: 1},
+			},
+		},
+		{
+			Name: "bird_bgp_route_changes",
+			Type: "counter",
+			Samples: []Sample{
+				{Labels: []Label{{"protocol", "peer_a"}, {"direction", "import"}}, Value: 1257},
+			},
+		},
+		{
+			Name: "bird_bgp_hold_timer_seconds",
+			Type: "gauge",
+			Samples: []Sample{
+				{Labels: []Label{{"protocol", "peer_a"}}, Value: 1.5},
+			},
+		},
+	}
+
+	expected := `bird_bgp_session_info,description=Transit\,\ "A"\=1,protocol=peer_a value=1 1768521600000000000
+bird_bgp_route_changes,direction=import,protocol=peer_a value=1257i 1768521600000000000
+bird_bgp_hold_timer_seconds,protocol=peer_a value=1.5 1768521600000000000
+`
+
+	var buf bytes.Buffer
+	if err := WriteInflux(&buf, families, time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("WriteInflux() error = %v", err)
+	}
+
+	if buf.String() != expected {
+		t.Errorf("WriteInflux() = %s, want %s", buf.String(), expected)
+	}
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+)
+
+const OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+func WriteOpenMetrics(w io.Writer, families []Family) error {
+	bw := bufio.NewWriter(w)
+
+	for _, family := range families {
+		if len(family.Samples) == 0 {
+			continue
+		}
+
+		bw.WriteString("# TYPE " + family.Name + " " + family.Type + "\n")
+		bw.WriteString("# HELP " + family.Name + " " + escapeLabelValue(family.Help) + "\n")
+
+		name := family.Name
+		if family.Type == "counter" {
+			name += "_total"
+		}
+
+		for _, sample := range family.Samples {
+			bw.WriteString(name)
+			writeLabels(bw, sample.Labels)
+			bw.WriteString(" " + formatValue(sample.Value) + "\n")
+		}
+	}
+
+	bw.WriteString("# EOF\n")
+
+	return bw.Flush()
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/LaunchPad-Network/birdparse"
+)
+
+func TestWriteOpenMetrics(t *testing.T) {
+	routes := []birdparse.Route{
+		{Network: "10.0.0.0/8", FromProtocol: "static4", Primary: true},
+		{Network: "10.1.0.0/16", FromProtocol: "ospf1", Primary: true},
+		{Network: "10.1.0.0/16", FromProtocol: "ospf2"},
+		{Network: "10.2.0.0/16", FromProtocol: "ospf1", Primary: true},
+	}
+
+	families := append(RouteFamilies(routes), BgpFamilies(testProtocols[:1])[8])
+
+	expected := `# TYPE bird_routes gauge
+# HELP bird_routes Number of routes by source protocol.
+bird_routes{protocol="ospf1"} 2
+bird_routes{protocol="ospf2"} 1
+bird_routes{protocol="static4"} 1
+# TYPE bird_routes_primary gauge
+# HELP bird_routes_primary Number of primary routes by source protocol.
+bird_routes_primary{protocol="ospf1"} 2
+bird_routes_primary{protocol="ospf2"} 0
+bird_routes_primary{protocol="static4"} 1
+# TYPE bird_bgp_route_changes counter
+# HELP bird_bgp_route_changes Route change statistics by direction, kind and result.
+bird_bgp_route_changes_total{protocol="peer_a",neighbor_as="64500",direction="import",kind="updates",result="received"} 1257
+bird_bgp_route_changes_total{protocol="peer_a",neighbor_as="64500",direction="import",kind="updates",result="rejected"} 0
+bird_bgp_route_changes_total{protocol="peer_a",neighbor_as="64500",direction="import",kind="updates",result="filtered"} 0
+bird_bgp_route_changes_total{protocol="peer_a",neighbor_as="64500",direction="import",kind="updates",result="ignored"} 331
+bird_bgp_route_changes_total{protocol="peer_a",neighbor_as="64500",direction="import",kind="updates",result="accepted"} 926
+# EOF
+`
+
+	var buf bytes.Buffer
+	if err := WriteOpenMetrics(&buf, families); err != nil {
+		t.Fatalf("WriteOpenMetrics() error = %v", err)
+	}
+
+	if buf.String() != expected {
+		t.Errorf("WriteOpenMetrics() = %s, want %s", buf.String(), expected)
+	}
+}
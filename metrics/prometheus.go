@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LaunchPad-Network/birdparse"
+)
+
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+func WritePrometheus(w io.Writer, families []Family) error {
+	bw := bufio.NewWriter(w)
+
+	for _, family := range families {
+		if len(family.Samples) == 0 {
+			continue
+		}
+
+		name := family.Name
+		if family.Type == "counter" {
+			name += "_total"
+		}
+
+		bw.WriteString("# HELP " + name + " " + escapeHelp(family.Help) + "\n")
+		bw.WriteString("# TYPE " + name + " " + family.Type + "\n")
+
+		for _, sample := range family.Samples {
+			bw.WriteString(name)
+			writeLabels(bw, sample.Labels)
+			bw.WriteString(" " + formatValue(sample.Value) + "\n")
+		}
+	}
+
+	return bw.Flush()
+}
+
+func Handler(source func(ctx context.Context) ([]birdparse.BgpProtocol, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		protocols, err := source(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", PrometheusContentType)
+		WritePrometheus(w, BgpFamilies(protocols))
+	})
+}
+
+func writeLabels(w *bufio.Writer, labels []Label) {
+	if len(labels) == 0 {
+		return
+	}
+
+	w.WriteByte('{')
+	for i, label := range labels {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		w.WriteString(label.Name + `="` + escapeLabelValue(label.Value) + `"`)
+	}
+	w.WriteByte('}')
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(s)
+}
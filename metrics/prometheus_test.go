@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LaunchPad-Network/birdparse"
+)
+
+var testProtocols = []birdparse.BgpProtocol{
+	{
+		Protocol:        "peer_a",
+		Description:     `Transit "A"`,
+		BgpState:        "Established",
+		NeighborAddress: "192.0.2.1",
+		NeighborAS:      64500,
+		NeighborID:      "192.0.2.1",
+		HoldTimer:       240,
+		HoldTimerNow:    211,
+		Keepalive:       80,
+		KeepaliveNow:    32,
+		Routes: &birdparse.BgpProtocolBgpRoutes{
+			Imported:  "31",
+			Filtered:  "0",
+			Exported:  "14",
+			Preferred: "31",
+		},
+		RouteChanges: &birdparse.BgpProtocolRouteChanges{
+			ImportUpdates: &birdparse.BgpProtocolRouteChangeDetail{
+				Received: "1257",
+				Rejected: "0",
+				Filtered: "0",
+				Ignored:  "331",
+				Accepted: "926",
+			},
+		},
+	},
+	{
+		Protocol:        "peer_b",
+		BgpState:        "Active",
+		NeighborAddress: "192.0.2.2",
+		NeighborAS:      64501,
+	},
+}
+
+func TestWritePrometheus(t *testing.T) {
+	expected := `# HELP bird_bgp_session_up Whether the BGP session is established.
+# TYPE bird_bgp_session_up gauge
+bird_bgp_session_up{protocol="peer_a",neighbor_as="64500"} 1
+bird_bgp_session_up{protocol="peer_b",neighbor_as="64501"} 0
+# HELP bird_bgp_session_info Static information about the BGP session.
+# TYPE bird_bgp_session_info gauge
+bird_bgp_session_info{protocol="peer_a",neighbor_as="64500",neighbor_address="192.0.2.1",neighbor_id="192.0.2.1",description="Transit \"A\""} 1
+bird_bgp_session_info{protocol="peer_b",neighbor_as="64501",neighbor_address="192.0.2.2",neighbor_id="",description=""} 1
+# HELP bird_bgp_state Current BGP state of the session.
+# TYPE bird_bgp_state gauge
+bird_bgp_state{protocol="peer_a",neighbor_as="64500",state="Idle"} 0
+bird_bgp_state{protocol="peer_a",neighbor_as="64500",state="Connect"} 0
+bird_bgp_state{protocol="peer_a",neighbor_as="64500",state="Active"} 0
+bird_bgp_state{protocol="peer_a",neighbor_as="64500",state="OpenSent"} 0
+bird_bgp_state{protocol="peer_a",neighbor_as="64500",state="OpenConfirm"} 0
+bird_bgp_state{protocol="peer_a",neighbor_as="64500",state="Established"} 1
+bird_bgp_state{protocol="peer_a",neighbor_as="64500",state="Close"} 0
+bird_bgp_state{protocol="peer_a",neighbor_as="64500",state="Passive"} 0
+bird_bgp_state{protocol="peer_b",neighbor_as="64501",state="Idle"} 0
+bird_bgp_state{protocol="peer_b",neighbor_as="64501",state="Connect"} 0
+bird_bgp_state{protocol="peer_b",neighbor_as="64501",state="Active"} 1
+bird_bgp_state{protocol="peer_b",neighbor_as="64501",state="OpenSent"} 0
+bird_bgp_state{protocol="peer_b",neighbor_as="64501",state="OpenConfirm"} 0
+bird_bgp_state{protocol="peer_b",neighbor_as="64501",state="Established"} 0
+bird_bgp_state{protocol="peer_b",neighbor_as="64501",state="Close"} 0
+bird_bgp_state{protocol="peer_b",neighbor_as="64501",state="Passive"} 0
+# HELP bird_bgp_hold_timer_seconds Negotiated hold timer.
+# TYPE bird_bgp_hold_timer_seconds gauge
+bird_bgp_hold_timer_seconds{protocol="peer_a",neighbor_as="64500"} 240
+# HELP bird_bgp_hold_timer_remaining_seconds Time left on the hold timer.
+# TYPE bird_bgp_hold_timer_remaining_seconds gauge
+bird_bgp_hold_timer_remaining_seconds{protocol="peer_a",neighbor_as="64500"} 211
+# HELP bird_bgp_keepalive_seconds Negotiated keepalive timer.
+# TYPE bird_bgp_keepalive_seconds gauge
+bird_bgp_keepalive_seconds{protocol="peer_a",neighbor_as="64500"} 80
+# HELP bird_bgp_keepalive_remaining_seconds Time left until the next keepalive.
+# TYPE bird_bgp_keepalive_remaining_seconds gauge
+bird_bgp_keepalive_remaining_seconds{protocol="peer_a",neighbor_as="64500"} 32
+# HELP bird_bgp_routes Number of routes in the channel by type.
+# TYPE bird_bgp_routes gauge
+bird_bgp_routes{protocol="peer_a",neighbor_as="64500",type="imported"} 31
+bird_bgp_routes{protocol="peer_a",neighbor_as="64500",type="filtered"} 0
+bird_bgp_routes{protocol="peer_a",neighbor_as="64500",type="exported"} 14
+bird_bgp_routes{protocol="peer_a",neighbor_as="64500",type="preferred"} 31
+# HELP bird_bgp_route_changes_total Route change statistics by direction, kind and result.
+# TYPE bird_bgp_route_changes_total counter
+bird_bgp_route_changes_total{protocol="peer_a",neighbor_as="64500",direction="import",kind="updates",result="received"} 1257
+bird_bgp_route_changes_total{protocol="peer_a",neighbor_as="64500",direction="import",kind="updates",result="rejected"} 0
+bird_bgp_route_changes_total{protocol="peer_a",neighbor_as="64500",direction="import",kind="updates",result="filtered"} 0
+bird_bgp_route_changes_total{protocol="peer_a",neighbor_as="64500",direction="import",kind="updates",result="ignored"} 331
+bird_bgp_route_changes_total{protocol="peer_a",neighbor_as="64500",direction="import",kind="updates",result="accepted"} 926
+`
+
+	var buf bytes.Buffer
+	if err := WritePrometheus(&buf, BgpFamilies(testProtocols)); err != nil {
+		t.Fatalf("WritePrometheus() error = %v", err)
+	}
+
+	if buf.String() != expected {
+		t.Errorf("WritePrometheus() = %s, want %s", buf.String(), expected)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	handler := Handler(func(ctx context.Context) ([]birdparse.BgpProtocol, error) {
+		return testProtocols, nil
+	})
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	if recorder.Code != 200 {
+		t.Fatalf("status = %d, want 200", recorder.Code)
+	}
+
+	if recorder.Header().Get("Content-Type") != PrometheusContentType {
+		t.Errorf("Content-Type = %q", recorder.Header().Get("Content-Type"))
+	}
+
+	if !strings.Contains(recorder.Body.String(), `bird_bgp_session_up{protocol="peer_a",neighbor_as="64500"} 1`) {
+		t.Errorf("body = %s, want session up sample", recorder.Body.String())
+	}
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"sort"
+
+	"github.com/LaunchPad-Network/birdparse"
+)
+
+func RouteFamilies(routes []birdparse.Route) []Family {
+	total := Family{Name: "bird_routes", Help: "Number of routes by source protocol.", Type: "gauge"}
+	primary := Family{Name: "bird_routes_primary", Help: "Number of primary routes by source protocol.", Type: "gauge"}
+
+	counts := make(map[string]int)
+	primaries := make(map[string]int)
+
+	for _, route := range routes {
+		counts[route.FromProtocol]++
+		if route.Primary {
+			primaries[route.FromProtocol]++
+		}
+	}
+
+	protocols := make([]string, 0, len(counts))
+	for protocol := range counts {
+		protocols = append(protocols, protocol)
+	}
+	sort.Strings(protocols)
+
+	for _, protocol := range protocols {
+		total.add(float64(counts[protocol]), Label{"protocol", protocol})
+		primary.add(float64(primaries[protocol]), Label{"protocol", protocol})
+	}
+
+	return []Family{total, primary}
+}
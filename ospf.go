@@ -0,0 +1,179 @@
+package birdparse
+
+import (
+	"regexp"
+	"strings"
+)
+
+func ParseOSPFNeighbors(data string) []OSPFNeighbor {
+	neighbors := []OSPFNeighbor{}
+	var protocol string
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if strings.HasPrefix(line, "BIRD") ||
+			strings.HasPrefix(line, "Access restricted") ||
+			strings.HasPrefix(line, "Router ID") {
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(\S+):$`).FindStringSubmatch(line); m != nil {
+			protocol = m[1]
+			continue
+		}
+
+		m := regexp.MustCompile(`^\s*([0-9.]+)\s+(\d+)\s+([\w-]+)(?:/([\w-]+))?\s+(\S+)\s+(\S+)\s+([0-9a-fA-F.:]+)`).FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		neighbors = append(neighbors, OSPFNeighbor{
+			Protocol:  protocol,
+			RouterID:  m[1],
+			Priority:  atoi(m[2]),
+			State:     m[3],
+			Role:      m[4],
+			DeadTimer: m[5],
+			Interface: m[6],
+			Address:   m[7],
+		})
+	}
+
+	return neighbors
+}
+
+func ParseOSPFInterfaces(data string) []OSPFInterface {
+	interfaces := []OSPFInterface{}
+	var current *OSPFInterface
+	var protocol string
+
+	flush := func() {
+		if current != nil {
+			interfaces = append(interfaces, *current)
+			current = nil
+		}
+	}
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if strings.HasPrefix(line, "BIRD") ||
+			strings.HasPrefix(line, "Access restricted") {
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(\S+):$`).FindStringSubmatch(line); m != nil {
+			flush()
+			protocol = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s*Interface\s+(\S+)\s+\((.*)\)$`).FindStringSubmatch(line); m != nil {
+			flush()
+			current = &OSPFInterface{
+				Protocol:  protocol,
+				Interface: m[1],
+				Network:   m[2],
+			}
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s*Virtual link\s+(.+)$`).FindStringSubmatch(line); m != nil {
+			flush()
+			current = &OSPFInterface{
+				Protocol:  protocol,
+				Interface: strings.TrimSpace(m[1]),
+			}
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Type:\s+(.+)$`).FindStringSubmatch(line); m != nil {
+			current.Type = strings.TrimSpace(m[1])
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Area:\s+([0-9.]+)`).FindStringSubmatch(line); m != nil {
+			current.Area = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+State:\s+(\w+)(\s+\(stub\))?`).FindStringSubmatch(line); m != nil {
+			current.State = m[1]
+			current.Stub = m[2] != ""
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Priority:\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+			current.Priority = atoi(m[1])
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Cost:\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+			current.Cost = atoi(m[1])
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+ECMP weight:\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+			current.ECMPWeight = atoi(m[1])
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Hello timer:\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+			current.HelloInterval = atoi(m[1])
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Poll timer:\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+			current.PollInterval = atoi(m[1])
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Wait timer:\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+			current.WaitInterval = atoi(m[1])
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Dead timer:\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+			current.DeadInterval = atoi(m[1])
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Retransmit timer:\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+			current.RetransmitInterval = atoi(m[1])
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Designated router \(ID\):\s+(\S+)$`).FindStringSubmatch(line); m != nil {
+			current.DesignatedRouterID = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Designated router \(IP\):\s+(\S+)$`).FindStringSubmatch(line); m != nil {
+			current.DesignatedRouterIP = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Backup designated router \(ID\):\s+(\S+)$`).FindStringSubmatch(line); m != nil {
+			current.BackupDesignatedRouterID = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Backup designated router \(IP\):\s+(\S+)$`).FindStringSubmatch(line); m != nil {
+			current.BackupDesignatedRouterIP = m[1]
+			continue
+		}
+	}
+
+	flush()
+
+	return interfaces
+}
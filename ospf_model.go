@@ -0,0 +1,38 @@
+package birdparse
+
+type OSPFNeighbor struct {
+	Protocol  string `json:"protocol"`
+	RouterID  string `json:"router_id"`
+	Priority  int    `json:"priority"`
+	State     string `json:"state"`
+	Role      string `json:"role"`
+	DeadTimer string `json:"dead_timer"`
+	Interface string `json:"interface"`
+	Address   string `json:"address"`
+}
+
+func (n OSPFNeighbor) IsFull() bool {
+	return n.State == "Full"
+}
+
+type OSPFInterface struct {
+	Protocol                 string `json:"protocol"`
+	Interface                string `json:"interface"`
+	Network                  string `json:"network"`
+	Type                     string `json:"type"`
+	Area                     string `json:"area"`
+	State                    string `json:"state"`
+	Stub                     bool   `json:"stub"`
+	Priority                 int    `json:"priority"`
+	Cost                     int    `json:"cost"`
+	ECMPWeight               int    `json:"ecmp_weight"`
+	HelloInterval            int    `json:"hello_interval"`
+	PollInterval             int    `json:"poll_interval"`
+	WaitInterval             int    `json:"wait_interval"`
+	DeadInterval             int    `json:"dead_interval"`
+	RetransmitInterval       int    `json:"retransmit_interval"`
+	DesignatedRouterID       string `json:"designated_router_id"`
+	DesignatedRouterIP       string `json:"designated_router_ip"`
+	BackupDesignatedRouterID string `json:"backup_designated_router_id"`
+	BackupDesignatedRouterIP string `json:"backup_designated_router_ip"`
+}
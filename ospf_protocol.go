@@ -0,0 +1,215 @@
+package birdparse
+
+import (
+	"regexp"
+	"strings"
+)
+
+func ParseOSPFProtocol(data string) OSPFProtocol {
+	result := OSPFProtocol{}
+	var channel *ProtocolChannel
+	var foreign bool
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if m := matchProtocolHeader(line, "OSPF"); m != nil {
+			result.Protocol = m[1]
+			result.Table = m[2]
+			result.State = m[3]
+
+			result.Connection = strings.TrimSpace(m[5])
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(\S+):$`).FindStringSubmatch(line); m != nil {
+			if result.Protocol == "" {
+				result.Protocol = m[1]
+			}
+			foreign = m[1] != result.Protocol
+			applyOSPFChannel(&result, channel)
+			channel = nil
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Description:\s+(.*)$`).FindStringSubmatch(line); m != nil {
+			result.Description = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Channel\s+(\S+)$`).FindStringSubmatch(line); m != nil {
+			channel = &ProtocolChannel{Name: m[1]}
+			continue
+		}
+
+		if channel != nil && applyChannelLine(channel, line) {
+			continue
+		}
+
+		if !foreign {
+			applyOSPFDetailLine(&result, line)
+		}
+	}
+
+	applyOSPFChannel(&result, channel)
+
+	return result
+}
+
+func ParseOSPFProtocols(data string) []OSPFProtocol {
+	var results []OSPFProtocol
+
+	for _, block := range splitProtocolBlocks(data, "OSPF") {
+		p := ParseOSPFProtocol(block)
+		if p.IsValid() {
+			results = append(results, p)
+		}
+	}
+
+	details := ParseOSPFDetails(data)
+	routerID := ParseStatus(data).RouterID
+
+	for i := range results {
+		for _, d := range details {
+			if d.Protocol == results[i].Protocol {
+				results[i].MergeDetails(d)
+			}
+		}
+
+		if results[i].RouterID == "" {
+			results[i].RouterID = routerID
+		}
+	}
+
+	return results
+}
+
+func ParseOSPFDetails(data string) []OSPFProtocol {
+	var results []OSPFProtocol
+	var current *OSPFProtocol
+	var found bool
+
+	flush := func() {
+		if current != nil && found {
+			results = append(results, *current)
+		}
+		current = nil
+		found = false
+	}
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if m := regexp.MustCompile(`^(\S+):$`).FindStringSubmatch(line); m != nil {
+			flush()
+			current = &OSPFProtocol{Protocol: m[1]}
+			continue
+		}
+
+		if matchProtocolHeader(line, `\S+`) != nil {
+			flush()
+			continue
+		}
+
+		if current != nil && applyOSPFDetailLine(current, line) {
+			found = true
+		}
+	}
+
+	flush()
+
+	return results
+}
+
+func applyOSPFChannel(p *OSPFProtocol, channel *ProtocolChannel) {
+	if channel == nil {
+		return
+	}
+
+	p.Channel = channel.Name
+	if channel.Table != "" {
+		p.Table = channel.Table
+	}
+	p.Preference = channel.Preference
+	p.InputFilter = channel.InputFilter
+	p.OutputFilter = channel.OutputFilter
+	p.Routes = channel.Routes
+	p.RouteChanges = channel.RouteChanges
+}
+
+func applyOSPFDetailLine(p *OSPFProtocol, line string) bool {
+	if m := regexp.MustCompile(`^\s*RFC1583 compatibility:\s+(\w+)$`).FindStringSubmatch(line); m != nil {
+		p.RFC1583Compatibility = m[1] == "enabled"
+		return true
+	}
+
+	if m := regexp.MustCompile(`^\s*Stub router:\s+(\w+)$`).FindStringSubmatch(line); m != nil {
+		p.StubRouter = m[1] == "Yes"
+		return true
+	}
+
+	if m := regexp.MustCompile(`^\s*RT scheduler tick:\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+		p.SchedulerTick = atoi(m[1])
+		return true
+	}
+
+	if m := regexp.MustCompile(`^\s*Number of areas:\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+		p.AreaCount = atoi(m[1])
+		return true
+	}
+
+	if m := regexp.MustCompile(`^\s*Number of LSAs in DB:\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+		p.LSACount = atoi(m[1])
+		return true
+	}
+
+	if m := regexp.MustCompile(`^\s*Area:\s+([0-9.]+)\s+\((\d+)\)\s*(\[BACKBONE\])?`).FindStringSubmatch(line); m != nil {
+		p.Areas = append(p.Areas, OSPFProtocolArea{
+			AreaID:   m[1],
+			Backbone: m[3] != "",
+		})
+		return true
+	}
+
+	if len(p.Areas) == 0 {
+		return false
+	}
+
+	area := &p.Areas[len(p.Areas)-1]
+
+	if m := regexp.MustCompile(`^\s+Stub:\s+(\w+)$`).FindStringSubmatch(line); m != nil {
+		area.Stub = m[1] == "Yes"
+		return true
+	}
+
+	if m := regexp.MustCompile(`^\s+NSSA:\s+(\w+)$`).FindStringSubmatch(line); m != nil {
+		area.NSSA = m[1] == "Yes"
+		return true
+	}
+
+	if m := regexp.MustCompile(`^\s+Transit:\s+(\w+)$`).FindStringSubmatch(line); m != nil {
+		area.Transit = m[1] == "Yes"
+		return true
+	}
+
+	if m := regexp.MustCompile(`^\s+Number of interfaces:\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+		area.Interfaces = atoi(m[1])
+		return true
+	}
+
+	if m := regexp.MustCompile(`^\s+Number of neighbors:\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+		area.Neighbors = atoi(m[1])
+		return true
+	}
+
+	if m := regexp.MustCompile(`^\s+Number of adjacent neighbors:\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+		area.AdjacentNeighbors = atoi(m[1])
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,54 @@
+package birdparse
+
+type OSPFProtocolArea struct {
+	AreaID            string `json:"area_id"`
+	Backbone          bool   `json:"backbone"`
+	Stub              bool   `json:"stub"`
+	NSSA              bool   `json:"nssa"`
+	Transit           bool   `json:"transit"`
+	Interfaces        int    `json:"interfaces"`
+	Neighbors         int    `json:"neighbors"`
+	AdjacentNeighbors int    `json:"adjacent_neighbors"`
+}
+
+type OSPFProtocol struct {
+	Protocol             string                   `json:"protocol"`
+	Table                string                   `json:"table"`
+	State                string                   `json:"state"`
+	Connection           string                   `json:"connection"`
+	Description          string                   `json:"description"`
+	Channel              string                   `json:"channel"`
+	Preference           int                      `json:"preference"`
+	InputFilter          string                   `json:"input_filter"`
+	OutputFilter         string                   `json:"output_filter"`
+	Routes               *BgpProtocolBgpRoutes    `json:"routes"`
+	RouteChanges         *BgpProtocolRouteChanges `json:"route_changes"`
+	RouterID             string                   `json:"router_id"`
+	RFC1583Compatibility bool                     `json:"rfc1583_compatibility"`
+	StubRouter           bool                     `json:"stub_router"`
+	SchedulerTick        int                      `json:"scheduler_tick"`
+	AreaCount            int                      `json:"area_count"`
+	LSACount             int                      `json:"lsa_count"`
+	Areas                []OSPFProtocolArea       `json:"areas"`
+}
+
+func (p OSPFProtocol) IsValid() bool {
+	if p.Protocol == "" &&
+		p.Table == "" {
+		return false
+	}
+
+	return true
+}
+
+func (p *OSPFProtocol) MergeDetails(details OSPFProtocol) {
+	if details.RouterID != "" {
+		p.RouterID = details.RouterID
+	}
+	p.RFC1583Compatibility = details.RFC1583Compatibility
+	p.StubRouter = details.StubRouter
+	p.SchedulerTick = details.SchedulerTick
+	p.AreaCount = details.AreaCount
+	p.LSACount = details.LSACount
+	p.Areas = details.Areas
+}
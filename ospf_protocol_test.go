@@ -0,0 +1,216 @@
+package birdparse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOSPFProtocols(t *testing.T) {
+	data := "BIRD 2.17.1 ready.\n" +
+		"Access restricted\n" +
+		"lpnet_ospf OSPF       master4    up     2026-01-16    Running\n" +
+		"  Channel ipv4\n" +
+		"    State:          UP\n" +
+		"    Table:          master4\n" +
+		"    Preference:     150\n" +
+		"    Input filter:   ospf_import\n" +
+		"    Output filter:  REJECT\n" +
+		"    Routes:         12 imported, 0 exported, 10 preferred\n" +
+		"    Route change stats:     received   rejected   filtered    ignored   accepted\n" +
+		"      Import updates:             45          0          0          0         45\n" +
+		"      Import withdraws:           33          0        ---          0         33\n" +
+		"      Export updates:              0          0          0        ---          0\n" +
+		"      Export withdraws:            0        ---        ---        ---          0\n" +
+		"lpnet_ospf:\n" +
+		"RFC1583 compatibility: disabled\n" +
+		"Stub router: No\n" +
+		"RT scheduler tick: 1\n" +
+		"Number of areas: 2\n" +
+		"Number of LSAs in DB:\t17\n" +
+		"\tArea: 0.0.0.0 (0) [BACKBONE]\n" +
+		"\t\tStub:\tNo\n" +
+		"\t\tNSSA:\tNo\n" +
+		"\t\tTransit:\tNo\n" +
+		"\t\tNumber of interfaces:\t3\n" +
+		"\t\tNumber of neighbors:\t2\n" +
+		"\t\tNumber of adjacent neighbors:\t2\n" +
+		"\tArea: 0.0.0.10 (10) \n" +
+		"\t\tStub:\tYes\n" +
+		"\t\tNSSA:\tNo\n" +
+		"\t\tTransit:\tNo\n" +
+		"\t\tNumber of interfaces:\t1\n" +
+		"\t\tNumber of neighbors:\t1\n" +
+		"\t\tNumber of adjacent neighbors:\t0\n" +
+		"\n" +
+		"AS213605_13_V6 BGP        ---        up     23:41:27.768    Established\n" +
+		"  BGP state:          Established\n" +
+		"  Channel ipv6\n" +
+		"    Table:          master6\n" +
+		"\n" +
+		"lpnet_ospf6 OSPF       master6    start  10:56:39.545  Alone\n" +
+		"  Description:    IGP v3\n" +
+		"  Channel ipv6\n" +
+		"    State:          UP\n" +
+		"    Table:          master6\n" +
+		"    Preference:     150\n" +
+		"    Input filter:   ACCEPT\n" +
+		"    Output filter:  REJECT\n" +
+		"    Routes:         0 imported, 0 exported, 0 preferred\n"
+
+	expected := []OSPFProtocol{
+		{
+			Protocol:     "lpnet_ospf",
+			Table:        "master4",
+			State:        "up",
+			Connection:   "Running",
+			Channel:      "ipv4",
+			Preference:   150,
+			InputFilter:  "ospf_import",
+			OutputFilter: "REJECT",
+			Routes: &BgpProtocolBgpRoutes{
+				Imported:  "12",
+				Exported:  "0",
+				Preferred: "10",
+			},
+			RouteChanges: &BgpProtocolRouteChanges{
+				ImportUpdates: &BgpProtocolRouteChangeDetail{
+					Received: "45",
+					Rejected: "0",
+					Filtered: "0",
+					Ignored:  "0",
+					Accepted: "45",
+				},
+				ImportWithdraws: &BgpProtocolRouteChangeDetail{
+					Received: "33",
+					Rejected: "0",
+					Filtered: "0",
+					Ignored:  "0",
+					Accepted: "33",
+				},
+				ExportUpdates: &BgpProtocolRouteChangeDetail{
+					Received: "0",
+					Rejected: "0",
+					Filtered: "0",
+					Ignored:  "0",
+					Accepted: "0",
+				},
+				ExportWithdraws: &BgpProtocolRouteChangeDetail{
+					Received: "0",
+					Rejected: "0",
+					Filtered: "0",
+					Ignored:  "0",
+					Accepted: "0",
+				},
+			},
+			RFC1583Compatibility: false,
+			StubRouter:           false,
+			SchedulerTick:        1,
+			AreaCount:            2,
+			LSACount:             17,
+			Areas: []OSPFProtocolArea{
+				{
+					AreaID:            "0.0.0.0",
+					Backbone:          true,
+					Interfaces:        3,
+					Neighbors:         2,
+					AdjacentNeighbors: 2,
+				},
+				{
+					AreaID:     "0.0.0.10",
+					Stub:       true,
+					Interfaces: 1,
+					Neighbors:  1,
+				},
+			},
+		},
+		{
+			Protocol:     "lpnet_ospf6",
+			Table:        "master6",
+			State:        "start",
+			Connection:   "Alone",
+			Description:  "IGP v3",
+			Channel:      "ipv6",
+			Preference:   150,
+			InputFilter:  "ACCEPT",
+			OutputFilter: "REJECT",
+			Routes: &BgpProtocolBgpRoutes{
+				Imported:  "0",
+				Exported:  "0",
+				Preferred: "0",
+			},
+		},
+	}
+
+	result := ParseOSPFProtocols(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseOSPFProtocols() = %+v, want %+v", result, expected)
+	}
+}
+
+func TestParseOSPFDetails(t *testing.T) {
+	protocols := "BIRD 2.17.1 ready.\n" +
+		"lpnet_ospf OSPF       master4    up     2026-01-16    Running\n" +
+		"  Channel ipv4\n" +
+		"    State:          UP\n" +
+		"    Table:          master4\n" +
+		"    Preference:     150\n" +
+		"AS213605_13_V6 BGP        ---        up     23:41:27.768    Established\n" +
+		"  BGP state:          Established\n"
+
+	details := "BIRD 2.17.1 ready.\n" +
+		"lpnet_ospf:\n" +
+		"RFC1583 compatibility: enabled\n" +
+		"Stub router: Yes\n" +
+		"RT scheduler tick: 2\n" +
+		"Number of areas: 1\n" +
+		"Number of LSAs in DB:\t5\n" +
+		"\tArea: 0.0.0.0 (0) [BACKBONE]\n" +
+		"\t\tStub:\tNo\n" +
+		"\t\tNSSA:\tNo\n" +
+		"\t\tTransit:\tNo\n" +
+		"\t\tNumber of interfaces:\t2\n" +
+		"\t\tNumber of neighbors:\t1\n" +
+		"\t\tNumber of adjacent neighbors:\t1\n"
+
+	status := "BIRD 2.17.1 ready.\n" +
+		"BIRD 2.17.1\n" +
+		"Router ID is 82.39.145.1\n" +
+		"Hostname is lpnet-rtr1\n" +
+		"Daemon is up and running\n"
+
+	expectedDetails := OSPFProtocol{
+		Protocol:             "lpnet_ospf",
+		RFC1583Compatibility: true,
+		StubRouter:           true,
+		SchedulerTick:        2,
+		AreaCount:            1,
+		LSACount:             5,
+		Areas: []OSPFProtocolArea{
+			{AreaID: "0.0.0.0", Backbone: true, Interfaces: 2, Neighbors: 1, AdjacentNeighbors: 1},
+		},
+	}
+
+	if result := ParseOSPFDetails(details); !reflect.DeepEqual(result, []OSPFProtocol{expectedDetails}) {
+		t.Errorf("ParseOSPFDetails() = %+v, want %+v", result, expectedDetails)
+	}
+
+	if result := ParseOSPFProtocols(protocols + details); result[0].AreaCount != 1 {
+		t.Errorf("ParseOSPFProtocols() = %+v, want details merged", result)
+	}
+
+	trailing := ParseOSPFProtocols("lpnet_ospf6 OSPF       master6    start  10:56:39.545  Alone\n" + details)
+	if len(trailing) != 1 || trailing[0].AreaCount != 0 || trailing[0].Areas != nil {
+		t.Errorf("ParseOSPFProtocols() = %+v, want details of another protocol ignored", trailing)
+	}
+
+	result := ParseOSPFProtocols(protocols + details + status)
+	if len(result) != 1 {
+		t.Fatalf("ParseOSPFProtocols() = %+v, want 1 protocol", result)
+	}
+
+	p := result[0]
+	if p.RouterID != "82.39.145.1" || p.AreaCount != 1 || p.LSACount != 5 || len(p.Areas) != 1 || !p.StubRouter || p.Preference != 150 {
+		t.Errorf("ParseOSPFProtocols() = %+v", p)
+	}
+}
@@ -0,0 +1,245 @@
+package birdparse
+
+import (
+	"regexp"
+	"strings"
+)
+
+type ospfStateEntity int
+
+const (
+	ospfStateEntityNone ospfStateEntity = iota
+	ospfStateEntityRouter
+	ospfStateEntityNetwork
+)
+
+func ParseOSPFState(data string) []OSPFState {
+	states := []OSPFState{}
+	var (
+		area      *OSPFStateArea
+		inOther   bool
+		entity    ospfStateEntity
+		routerIdx int
+		netIdx    int
+	)
+
+	state := func() *OSPFState {
+		if len(states) == 0 {
+			states = append(states, OSPFState{})
+		}
+		return &states[len(states)-1]
+	}
+
+	routers := func() *[]OSPFStateRouter {
+		if inOther {
+			return &state().OtherASBRs
+		}
+		if area == nil {
+			return nil
+		}
+		return &area.Routers
+	}
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if strings.HasPrefix(line, "BIRD") ||
+			strings.HasPrefix(line, "Access restricted") {
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		depth := len(line) - len(strings.TrimLeft(line, "\t"))
+
+		if depth == 0 {
+			entity = ospfStateEntityNone
+
+			if m := regexp.MustCompile(`^(\S+):$`).FindStringSubmatch(trimmed); m != nil {
+				states = append(states, OSPFState{Protocol: m[1]})
+				area = nil
+				inOther = false
+				continue
+			}
+
+			if m := regexp.MustCompile(`^area\s+(\S+)$`).FindStringSubmatch(trimmed); m != nil {
+				s := state()
+				s.Areas = append(s.Areas, OSPFStateArea{AreaID: m[1]})
+				area = &s.Areas[len(s.Areas)-1]
+				inOther = false
+				continue
+			}
+
+			if trimmed == "other ASBRs" {
+				state()
+				area = nil
+				inOther = true
+			}
+			continue
+		}
+
+		if depth == 1 {
+			entity = ospfStateEntityNone
+
+			if m := regexp.MustCompile(`^router\s+(\S+)$`).FindStringSubmatch(trimmed); m != nil {
+				list := routers()
+				if list == nil {
+					continue
+				}
+				*list = append(*list, OSPFStateRouter{RouterID: m[1]})
+				entity = ospfStateEntityRouter
+				routerIdx = len(*list) - 1
+				continue
+			}
+
+			if m := regexp.MustCompile(`^network\s+(\S+)$`).FindStringSubmatch(trimmed); m != nil {
+				if area == nil {
+					continue
+				}
+				area.Networks = append(area.Networks, OSPFStateNetwork{Network: m[1]})
+				entity = ospfStateEntityNetwork
+				netIdx = len(area.Networks) - 1
+			}
+			continue
+		}
+
+		switch entity {
+		case ospfStateEntityRouter:
+			parseOSPFStateRouterLine(&(*routers())[routerIdx], trimmed)
+		case ospfStateEntityNetwork:
+			parseOSPFStateNetworkLine(&area.Networks[netIdx], trimmed)
+		}
+	}
+
+	return states
+}
+
+func parseOSPFStateRouterLine(router *OSPFStateRouter, line string) {
+	if m := regexp.MustCompile(`^distance\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+		router.Distance = atoi(m[1])
+		return
+	}
+
+	if line == "unreachable" {
+		router.Unreachable = true
+		return
+	}
+
+	if m := regexp.MustCompile(`^(router|network|virtual link|stubnet|xnetwork|xrouter)\s+(\S+)\s+metric\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+		link := OSPFStateLink{
+			ID:     m[2],
+			Metric: atoi(m[3]),
+		}
+
+		switch m[1] {
+		case "router":
+			router.Routers = append(router.Routers, link)
+		case "network":
+			router.Networks = append(router.Networks, link)
+		case "virtual link":
+			router.VirtualLinks = append(router.VirtualLinks, link)
+		case "stubnet":
+			router.StubNetworks = append(router.StubNetworks, link)
+		case "xnetwork":
+			router.InterAreaNetworks = append(router.InterAreaNetworks, link)
+		case "xrouter":
+			router.InterAreaRouters = append(router.InterAreaRouters, link)
+		}
+		return
+	}
+
+	if m := regexp.MustCompile(`^(external|nssa-ext)\s+(\S+)\s+metric(2?)\s+(\d+)(?:\s+via\s+(\S+))?(?:\s+tag\s+(\S+))?$`).FindStringSubmatch(line); m != nil {
+		external := OSPFStateExternal{
+			Network:    m[2],
+			NSSA:       m[1] == "nssa-ext",
+			MetricType: 1,
+			Metric:     atoi(m[4]),
+			Via:        m[5],
+			Tag:        m[6],
+		}
+		if m[3] == "2" {
+			external.MetricType = 2
+		}
+		router.Externals = append(router.Externals, external)
+	}
+}
+
+func parseOSPFStateNetworkLine(network *OSPFStateNetwork, line string) {
+	if m := regexp.MustCompile(`^dr\s+(\S+)$`).FindStringSubmatch(line); m != nil {
+		network.DesignatedRouter = m[1]
+		return
+	}
+
+	if m := regexp.MustCompile(`^distance\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+		network.Distance = atoi(m[1])
+		return
+	}
+
+	if line == "unreachable" {
+		network.Unreachable = true
+		return
+	}
+
+	if m := regexp.MustCompile(`^router\s+(\S+)$`).FindStringSubmatch(line); m != nil {
+		network.Routers = append(network.Routers, m[1])
+	}
+}
+
+func ParseOSPFLSADB(data string) []OSPFLSA {
+	lsas := []OSPFLSA{}
+	var protocol, scope, scopeID string
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if strings.HasPrefix(line, "BIRD") ||
+			strings.HasPrefix(line, "Access restricted") {
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+
+		if m := regexp.MustCompile(`^(\S+):$`).FindStringSubmatch(trimmed); m != nil {
+			protocol = m[1]
+			continue
+		}
+
+		if trimmed == "Global" {
+			scope = "global"
+			scopeID = ""
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(Area|Interface)\s+(\S+)$`).FindStringSubmatch(trimmed); m != nil {
+			scope = strings.ToLower(m[1])
+			scopeID = m[2]
+			continue
+		}
+
+		m := regexp.MustCompile(`^([0-9a-fA-F]{4})\s+([0-9.]+)\s+([0-9.]+)\s+([0-9a-fA-F]{8})\s+(\d+)\s+([0-9a-fA-F]{4})$`).FindStringSubmatch(trimmed)
+		if m == nil {
+			continue
+		}
+
+		lsas = append(lsas, OSPFLSA{
+			Protocol: protocol,
+			Scope:    scope,
+			ScopeID:  scopeID,
+			Type:     m[1],
+			LSID:     m[2],
+			Router:   m[3],
+			Sequence: m[4],
+			Age:      atoi(m[5]),
+			Checksum: m[6],
+		})
+	}
+
+	return lsas
+}
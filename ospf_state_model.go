@@ -0,0 +1,83 @@
+package birdparse
+
+type OSPFStateLink struct {
+	ID     string `json:"id"`
+	Metric int    `json:"metric"`
+}
+
+type OSPFStateExternal struct {
+	Network    string `json:"network"`
+	NSSA       bool   `json:"nssa"`
+	MetricType int    `json:"metric_type"`
+	Metric     int    `json:"metric"`
+	Via        string `json:"via"`
+	Tag        string `json:"tag"`
+}
+
+type OSPFStateRouter struct {
+	RouterID          string              `json:"router_id"`
+	Distance          int                 `json:"distance"`
+	Unreachable       bool                `json:"unreachable"`
+	Routers           []OSPFStateLink     `json:"routers"`
+	Networks          []OSPFStateLink     `json:"networks"`
+	VirtualLinks      []OSPFStateLink     `json:"virtual_links"`
+	StubNetworks      []OSPFStateLink     `json:"stub_networks"`
+	InterAreaNetworks []OSPFStateLink     `json:"inter_area_networks"`
+	InterAreaRouters  []OSPFStateLink     `json:"inter_area_routers"`
+	Externals         []OSPFStateExternal `json:"externals"`
+}
+
+type OSPFStateNetwork struct {
+	Network          string   `json:"network"`
+	DesignatedRouter string   `json:"designated_router"`
+	Distance         int      `json:"distance"`
+	Unreachable      bool     `json:"unreachable"`
+	Routers          []string `json:"routers"`
+}
+
+type OSPFStateArea struct {
+	AreaID   string             `json:"area_id"`
+	Routers  []OSPFStateRouter  `json:"routers"`
+	Networks []OSPFStateNetwork `json:"networks"`
+}
+
+type OSPFState struct {
+	Protocol   string            `json:"protocol"`
+	Areas      []OSPFStateArea   `json:"areas"`
+	OtherASBRs []OSPFStateRouter `json:"other_asbrs"`
+}
+
+type OSPFLSA struct {
+	Protocol string `json:"protocol"`
+	Scope    string `json:"scope"`
+	ScopeID  string `json:"scope_id"`
+	Type     string `json:"type"`
+	LSID     string `json:"ls_id"`
+	Router   string `json:"router"`
+	Sequence string `json:"sequence"`
+	Age      int    `json:"age"`
+	Checksum string `json:"checksum"`
+}
+
+func (l OSPFLSA) TypeName() string {
+	switch l.Type {
+	case "0001", "2001":
+		return "router"
+	case "0002", "2002":
+		return "network"
+	case "0003", "2003":
+		return "summary-net"
+	case "0004", "2004":
+		return "summary-asbr"
+	case "0005", "4005":
+		return "external"
+	case "0007", "2007":
+		return "nssa-external"
+	case "0008":
+		return "link"
+	case "2009":
+		return "prefix"
+	}
+
+	return "unknown"
+}
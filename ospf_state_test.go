@@ -0,0 +1,167 @@
+package birdparse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOSPFState(t *testing.T) {
+	data := "BIRD 2.17.1 ready.\n" +
+		"lpnet_ospf:\n" +
+		"\n" +
+		"area 0.0.0.0\n" +
+		"\n" +
+		"\trouter 82.39.145.1\n" +
+		"\t\tdistance 0\n" +
+		"\t\trouter 82.39.145.2 metric 10\n" +
+		"\t\tnetwork 10.151.104.0/24 metric 10\n" +
+		"\t\tstubnet 10.0.0.1/32 metric 0\n" +
+		"\t\texternal 0.0.0.0/0 metric2 10000\n" +
+		"\t\texternal 192.0.2.0/24 metric 20 via 10.151.104.9 tag 0000002a\n" +
+		"\n" +
+		"\trouter 82.39.145.2\n" +
+		"\t\tdistance 10\n" +
+		"\t\trouter 82.39.145.1 metric 10\n" +
+		"\n" +
+		"\trouter 82.39.145.3\n" +
+		"\t\tunreachable\n" +
+		"\n" +
+		"\tnetwork 10.151.104.0/24\n" +
+		"\t\tdr 82.39.145.1\n" +
+		"\t\tdistance 10\n" +
+		"\t\trouter 82.39.145.1\n" +
+		"\t\trouter 82.39.145.2\n" +
+		"\n" +
+		"other ASBRs\n" +
+		"\n" +
+		"\trouter 82.39.145.9\n" +
+		"\t\tnssa-ext 198.51.100.0/24 metric2 100\n"
+
+	expected := []OSPFState{
+		{
+			Protocol: "lpnet_ospf",
+			Areas: []OSPFStateArea{
+				{
+					AreaID: "0.0.0.0",
+					Routers: []OSPFStateRouter{
+						{
+							RouterID:     "82.39.145.1",
+							Routers:      []OSPFStateLink{{ID: "82.39.145.2", Metric: 10}},
+							Networks:     []OSPFStateLink{{ID: "10.151.104.0/24", Metric: 10}},
+							StubNetworks: []OSPFStateLink{{ID: "10.0.0.1/32", Metric: 0}},
+							Externals: []OSPFStateExternal{
+								{
+									Network:    "0.0.0.0/0",
+									MetricType: 2,
+									Metric:     10000,
+								},
+								{
+									Network:    "192.0.2.0/24",
+									MetricType: 1,
+									Metric:     20,
+									Via:        "10.151.104.9",
+									Tag:        "0000002a",
+								},
+							},
+						},
+						{
+							RouterID: "82.39.145.2",
+							Distance: 10,
+							Routers:  []OSPFStateLink{{ID: "82.39.145.1", Metric: 10}},
+						},
+						{
+							RouterID:    "82.39.145.3",
+							Unreachable: true,
+						},
+					},
+					Networks: []OSPFStateNetwork{
+						{
+							Network:          "10.151.104.0/24",
+							DesignatedRouter: "82.39.145.1",
+							Distance:         10,
+							Routers:          []string{"82.39.145.1", "82.39.145.2"},
+						},
+					},
+				},
+			},
+			OtherASBRs: []OSPFStateRouter{
+				{
+					RouterID: "82.39.145.9",
+					Externals: []OSPFStateExternal{
+						{
+							Network:    "198.51.100.0/24",
+							NSSA:       true,
+							MetricType: 2,
+							Metric:     100,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result := ParseOSPFState(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseOSPFState() = %+v, want %+v", result, expected)
+	}
+}
+
+func TestParseOSPFLSADB(t *testing.T) {
+	data := "BIRD 2.17.1 ready.\n" +
+		"lpnet_ospf:\n" +
+		"\n" +
+		"Global\n" +
+		" Type   LS ID           Router          Sequence   Age  Checksum\n" +
+		" 0005  0.0.0.0         82.39.145.1      80000003   145    7f31\n" +
+		"\n" +
+		"Area 0.0.0.0\n" +
+		" Type   LS ID           Router          Sequence   Age  Checksum\n" +
+		" 0001  82.39.145.1     82.39.145.1      8000000a   310    a2c4\n" +
+		" 0002  10.151.104.1    82.39.145.1      80000002  1022    51be\n"
+
+	expected := []OSPFLSA{
+		{
+			Protocol: "lpnet_ospf",
+			Scope:    "global",
+			Type:     "0005",
+			LSID:     "0.0.0.0",
+			Router:   "82.39.145.1",
+			Sequence: "80000003",
+			Age:      145,
+			Checksum: "7f31",
+		},
+		{
+			Protocol: "lpnet_ospf",
+			Scope:    "area",
+			ScopeID:  "0.0.0.0",
+			Type:     "0001",
+			LSID:     "82.39.145.1",
+			Router:   "82.39.145.1",
+			Sequence: "8000000a",
+			Age:      310,
+			Checksum: "a2c4",
+		},
+		{
+			Protocol: "lpnet_ospf",
+			Scope:    "area",
+			ScopeID:  "0.0.0.0",
+			Type:     "0002",
+			LSID:     "10.151.104.1",
+			Router:   "82.39.145.1",
+			Sequence: "80000002",
+			Age:      1022,
+			Checksum: "51be",
+		},
+	}
+
+	result := ParseOSPFLSADB(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseOSPFLSADB() = %+v, want %+v", result, expected)
+	}
+
+	if name := result[0].TypeName(); name != "external" {
+		t.Errorf("TypeName() = %q, want %q", name, "external")
+	}
+}
@@ -0,0 +1,138 @@
+package birdparse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOSPFNeighbors(t *testing.T) {
+	data := "BIRD 2.17.1 ready.\n" +
+		"Access restricted\n" +
+		"lpnet_ospf:\n" +
+		"Router ID   \tPri\t     State     \tDTime\tInterface  Router IP\n" +
+		"82.39.145.1 \t  1\tFull/DR        \t00:36\teth0       10.151.104.1\n" +
+		"82.39.145.7 \t  0\tInit/Other     \t00:39\teth0       10.151.104.7\n" +
+		"82.39.145.9 \t  1\t2-Way/DROther  \t00:34\teth0       10.151.104.9\n" +
+		"lpnet_ospf6:\n" +
+		"Router ID   \tPri\t     State     \tDTime\tInterface  Router IP\n" +
+		"82.39.145.1 \t  1\tFull/PtP       \t00:31\ttyoe20     fe80::200:5efe:1797:6804\n"
+
+	expected := []OSPFNeighbor{
+		{
+			Protocol:  "lpnet_ospf",
+			RouterID:  "82.39.145.1",
+			Priority:  1,
+			State:     "Full",
+			Role:      "DR",
+			DeadTimer: "00:36",
+			Interface: "eth0",
+			Address:   "10.151.104.1",
+		},
+		{
+			Protocol:  "lpnet_ospf",
+			RouterID:  "82.39.145.7",
+			Priority:  0,
+			State:     "Init",
+			Role:      "Other",
+			DeadTimer: "00:39",
+			Interface: "eth0",
+			Address:   "10.151.104.7",
+		},
+		{
+			Protocol:  "lpnet_ospf",
+			RouterID:  "82.39.145.9",
+			Priority:  1,
+			State:     "2-Way",
+			Role:      "DROther",
+			DeadTimer: "00:34",
+			Interface: "eth0",
+			Address:   "10.151.104.9",
+		},
+		{
+			Protocol:  "lpnet_ospf6",
+			RouterID:  "82.39.145.1",
+			Priority:  1,
+			State:     "Full",
+			Role:      "PtP",
+			DeadTimer: "00:31",
+			Interface: "tyoe20",
+			Address:   "fe80::200:5efe:1797:6804",
+		},
+	}
+
+	result := ParseOSPFNeighbors(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseOSPFNeighbors() = %+v, want %+v", result, expected)
+	}
+}
+
+func TestParseOSPFInterfaces(t *testing.T) {
+	data := "BIRD 2.17.1 ready.\n" +
+		"lpnet_ospf:\n" +
+		"Interface eth0 (10.151.104.0/24)\n" +
+		"\tType: broadcast\n" +
+		"\tArea: 0.0.0.0 (0)\n" +
+		"\tState: backup\n" +
+		"\tPriority: 1\n" +
+		"\tCost: 10\n" +
+		"\tHello timer: 10\n" +
+		"\tWait timer: 40\n" +
+		"\tDead timer: 40\n" +
+		"\tRetransmit timer: 5\n" +
+		"\tDesignated router (ID): 82.39.145.1\n" +
+		"\tDesignated router (IP): 10.151.104.1\n" +
+		"\tBackup designated router (ID): 82.39.145.2\n" +
+		"\tBackup designated router (IP): 10.151.104.2\n" +
+		"Interface lo (10.0.0.2/32)\n" +
+		"\tType: ptp\n" +
+		"\tArea: 0.0.0.0 (0)\n" +
+		"\tState: ptp (stub)\n" +
+		"\tPriority: 1\n" +
+		"\tCost: 0\n" +
+		"\tHello timer: 10\n" +
+		"\tWait timer: 40\n" +
+		"\tDead timer: 40\n" +
+		"\tRetransmit timer: 5\n"
+
+	expected := []OSPFInterface{
+		{
+			Protocol:                 "lpnet_ospf",
+			Interface:                "eth0",
+			Network:                  "10.151.104.0/24",
+			Type:                     "broadcast",
+			Area:                     "0.0.0.0",
+			State:                    "backup",
+			Priority:                 1,
+			Cost:                     10,
+			HelloInterval:            10,
+			WaitInterval:             40,
+			DeadInterval:             40,
+			RetransmitInterval:       5,
+			DesignatedRouterID:       "82.39.145.1",
+			DesignatedRouterIP:       "10.151.104.1",
+			BackupDesignatedRouterID: "82.39.145.2",
+			BackupDesignatedRouterIP: "10.151.104.2",
+		},
+		{
+			Protocol:           "lpnet_ospf",
+			Interface:          "lo",
+			Network:            "10.0.0.2/32",
+			Type:               "ptp",
+			Area:               "0.0.0.0",
+			State:              "ptp",
+			Stub:               true,
+			Priority:           1,
+			HelloInterval:      10,
+			WaitInterval:       40,
+			DeadInterval:       40,
+			RetransmitInterval: 5,
+		},
+	}
+
+	result := ParseOSPFInterfaces(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseOSPFInterfaces() = %+v, want %+v", result, expected)
+	}
+}
@@ -0,0 +1,101 @@
+package birdparse
+
+import (
+	"regexp"
+	"strings"
+)
+
+func ParsePipeProtocol(data string) PipeProtocol {
+	result := PipeProtocol{}
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if m := matchProtocolHeader(line, "Pipe"); m != nil {
+			result.Protocol = m[1]
+			result.Table = m[2]
+			result.State = m[3]
+
+			result.Connection = strings.TrimSpace(m[5])
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Description:\s+(.*)$`).FindStringSubmatch(line); m != nil {
+			result.Description = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Table:\s+(.*)$`).FindStringSubmatch(line); m != nil {
+			result.Table = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Peer table:\s+(.*)$`).FindStringSubmatch(line); m != nil {
+			result.PeerTable = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Import state:\s+(\w+)$`).FindStringSubmatch(line); m != nil {
+			result.ImportState = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Export state:\s+(\w+)$`).FindStringSubmatch(line); m != nil {
+			result.ExportState = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Import filter:\s+([^\s]+)$`).FindStringSubmatch(line); m != nil {
+			result.ImportFilter = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Export filter:\s+([^\s]+)$`).FindStringSubmatch(line); m != nil {
+			result.ExportFilter = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Import limit:\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+			result.ImportLimit = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+Action:\s+(\w+)$`).FindStringSubmatch(line); m != nil {
+			result.LimitAction = m[1]
+			continue
+		}
+
+		if routes := parseChannelRoutes(line); routes != nil {
+			result.Routes = routes
+			continue
+		}
+
+		if changes, ok := applyRouteChangeLine(result.RouteChanges, line); ok {
+			result.RouteChanges = changes
+			continue
+		}
+	}
+
+	if result.PeerTable == "" {
+		if m := regexp.MustCompile(`^(\S+)\s+<=>\s+(\S+)$`).FindStringSubmatch(result.Connection); m != nil {
+			result.PeerTable = m[2]
+		}
+	}
+
+	return result
+}
+
+func ParsePipeProtocols(data string) []PipeProtocol {
+	var results []PipeProtocol
+
+	for _, block := range splitProtocolBlocks(data, "Pipe") {
+		p := ParsePipeProtocol(block)
+		if p.IsValid() {
+			results = append(results, p)
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,27 @@
+package birdparse
+
+type PipeProtocol struct {
+	Protocol     string                   `json:"protocol"`
+	Table        string                   `json:"table"`
+	PeerTable    string                   `json:"peer_table"`
+	State        string                   `json:"state"`
+	Connection   string                   `json:"connection"`
+	Description  string                   `json:"description"`
+	ImportState  string                   `json:"import_state"`
+	ExportState  string                   `json:"export_state"`
+	ImportFilter string                   `json:"import_filter"`
+	ExportFilter string                   `json:"export_filter"`
+	ImportLimit  string                   `json:"import_limit"`
+	LimitAction  string                   `json:"limit_action"`
+	Routes       *BgpProtocolBgpRoutes    `json:"routes"`
+	RouteChanges *BgpProtocolRouteChanges `json:"route_changes"`
+}
+
+func (p PipeProtocol) IsValid() bool {
+	if p.Protocol == "" &&
+		p.Table == "" {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,98 @@
+package birdparse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePipeProtocols(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+Access restricted
+pipe_vrf1  Pipe       ---        up     2026-01-16    master4 <=> vrf1
+  Channel main
+    Table:          master4
+    Peer table:     vrf1
+    Import state:   UP
+    Export state:   UP
+    Import filter:  vrf1_import
+    Export filter:  ACCEPT
+    Routes:         5 imported, 8 exported
+    Route change stats:     received   rejected   filtered    ignored   accepted
+      Import updates:             12          0          2          0         10
+      Import withdraws:            7          0        ---          0          7
+      Export updates:             20          0          0          0         20
+      Export withdraws:           12          0        ---          0         12
+pipe_vrf2  Pipe       ---        down   2026-01-16    master4 <=> vrf2
+  Channel main
+    Table:          master4
+    Peer table:     vrf2
+    Import state:   DOWN
+    Export state:   DOWN
+    Import filter:  REJECT
+    Export filter:  REJECT`
+
+	expected := []PipeProtocol{
+		{
+			Protocol:     "pipe_vrf1",
+			Table:        "master4",
+			PeerTable:    "vrf1",
+			State:        "up",
+			Connection:   "master4 <=> vrf1",
+			ImportState:  "UP",
+			ExportState:  "UP",
+			ImportFilter: "vrf1_import",
+			ExportFilter: "ACCEPT",
+			Routes: &BgpProtocolBgpRoutes{
+				Imported: "5",
+				Exported: "8",
+			},
+			RouteChanges: &BgpProtocolRouteChanges{
+				ImportUpdates: &BgpProtocolRouteChangeDetail{
+					Received: "12",
+					Rejected: "0",
+					Filtered: "2",
+					Ignored:  "0",
+					Accepted: "10",
+				},
+				ImportWithdraws: &BgpProtocolRouteChangeDetail{
+					Received: "7",
+					Rejected: "0",
+					Filtered: "0",
+					Ignored:  "0",
+					Accepted: "7",
+				},
+				ExportUpdates: &BgpProtocolRouteChangeDetail{
+					Received: "20",
+					Rejected: "0",
+					Filtered: "0",
+					Ignored:  "0",
+					Accepted: "20",
+				},
+				ExportWithdraws: &BgpProtocolRouteChangeDetail{
+					Received: "12",
+					Rejected: "0",
+					Filtered: "0",
+					Ignored:  "0",
+					Accepted: "12",
+				},
+			},
+		},
+		{
+			Protocol:     "pipe_vrf2",
+			Table:        "master4",
+			PeerTable:    "vrf2",
+			State:        "down",
+			Connection:   "master4 <=> vrf2",
+			ImportState:  "DOWN",
+			ExportState:  "DOWN",
+			ImportFilter: "REJECT",
+			ExportFilter: "REJECT",
+		},
+	}
+
+	result := ParsePipeProtocols(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParsePipeProtocols() = %+v, want %+v", result, expected)
+	}
+}
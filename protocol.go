@@ -0,0 +1,138 @@
+package birdparse
+
+import (
+	"regexp"
+	"strings"
+)
+
+func matchProtocolHeader(line string, proto string) []string {
+	headerRE := regexp.MustCompile(`^(\S+)\s+` + proto + `\s+([-\w]+|\.{3,}|-+)\s+(\w+)\s+([0-9]{4}-[0-9]{2}-[0-9]{2}|[0-9]{1,2}:[0-9]{1,2}:[0-9]{1,2}(?:\.[0-9]{1,3})?)\s*(.*)$`)
+	return headerRE.FindStringSubmatch(line)
+}
+
+func splitProtocolBlocks(data string, proto string) []string {
+	var (
+		blocks       []string
+		currentBlock []string
+		inBlock      bool
+	)
+
+	lines := strings.Split(data, "\n")
+
+	for _, raw := range lines {
+		line := strings.TrimRight(raw, "\r")
+
+		if strings.HasPrefix(line, "BIRD") ||
+			strings.HasPrefix(line, "Access restricted") {
+			continue
+		}
+
+		if matchProtocolHeader(line, `\S+`) != nil {
+			if inBlock && len(currentBlock) > 0 {
+				blocks = append(blocks, strings.Join(currentBlock, "\n"))
+			}
+
+			inBlock = matchProtocolHeader(line, proto) != nil
+			currentBlock = nil
+			if inBlock {
+				currentBlock = []string{line}
+			}
+			continue
+		}
+
+		if inBlock && strings.TrimSpace(line) != "" {
+			currentBlock = append(currentBlock, line)
+		}
+	}
+
+	if inBlock && len(currentBlock) > 0 {
+		blocks = append(blocks, strings.Join(currentBlock, "\n"))
+	}
+
+	return blocks
+}
+
+func parseChannelRoutes(line string) *BgpProtocolBgpRoutes {
+	m := regexp.MustCompile(`^\s+Routes:\s+(\d+)\s+imported,\s+(?:(\d+)\s+filtered,\s+)?(\d+)\s+exported(?:,\s+(\d+)\s+preferred)?`).FindStringSubmatch(line)
+	if m == nil {
+		return nil
+	}
+
+	return &BgpProtocolBgpRoutes{
+		Imported:  m[1],
+		Filtered:  m[2],
+		Exported:  m[3],
+		Preferred: m[4],
+	}
+}
+
+func applyRouteChangeLine(changes *BgpProtocolRouteChanges, line string) (*BgpProtocolRouteChanges, bool) {
+	m := regexp.MustCompile(`^\s+(Import|Export) (updates|withdraws):\s+(\d+|--+)\s+(\d+|--+)\s+(\d+|--+)\s+(\d+|--+)\s+(\d+|--+)$`).FindStringSubmatch(line)
+	if m == nil {
+		return changes, false
+	}
+
+	if changes == nil {
+		changes = &BgpProtocolRouteChanges{}
+	}
+
+	detail := &BgpProtocolRouteChangeDetail{
+		Received: parseOptionalIntAsString(m[3]),
+		Rejected: parseOptionalIntAsString(m[4]),
+		Filtered: parseOptionalIntAsString(m[5]),
+		Ignored:  parseOptionalIntAsString(m[6]),
+		Accepted: parseOptionalIntAsString(m[7]),
+	}
+
+	switch m[1] + " " + m[2] {
+	case "Import updates":
+		changes.ImportUpdates = detail
+	case "Import withdraws":
+		changes.ImportWithdraws = detail
+	case "Export updates":
+		changes.ExportUpdates = detail
+	case "Export withdraws":
+		changes.ExportWithdraws = detail
+	}
+
+	return changes, true
+}
+
+func applyChannelLine(channel *ProtocolChannel, line string) bool {
+	if m := regexp.MustCompile(`^\s+State:\s+(\w+)$`).FindStringSubmatch(line); m != nil {
+		channel.State = m[1]
+		return true
+	}
+
+	if m := regexp.MustCompile(`^\s+Table:\s+(.*)$`).FindStringSubmatch(line); m != nil {
+		channel.Table = m[1]
+		return true
+	}
+
+	if m := regexp.MustCompile(`^\s+Preference:\s+(\d+)$`).FindStringSubmatch(line); m != nil {
+		channel.Preference = atoi(m[1])
+		return true
+	}
+
+	if m := regexp.MustCompile(`^\s+Input filter:\s+([^\s]+)$`).FindStringSubmatch(line); m != nil {
+		channel.InputFilter = m[1]
+		return true
+	}
+
+	if m := regexp.MustCompile(`^\s+Output filter:\s+([^\s]+)$`).FindStringSubmatch(line); m != nil {
+		channel.OutputFilter = m[1]
+		return true
+	}
+
+	if routes := parseChannelRoutes(line); routes != nil {
+		channel.Routes = routes
+		return true
+	}
+
+	if changes, ok := applyRouteChangeLine(channel.RouteChanges, line); ok {
+		channel.RouteChanges = changes
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,12 @@
+package birdparse
+
+type ProtocolChannel struct {
+	Name         string                   `json:"name"`
+	State        string                   `json:"state"`
+	Table        string                   `json:"table"`
+	Preference   int                      `json:"preference"`
+	InputFilter  string                   `json:"input_filter"`
+	OutputFilter string                   `json:"output_filter"`
+	Routes       *BgpProtocolBgpRoutes    `json:"routes"`
+	RouteChanges *BgpProtocolRouteChanges `json:"route_changes"`
+}
@@ -0,0 +1,87 @@
+package birdparse
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+func ParseRIPInterfaces(data string) []RIPInterface {
+	interfaces := []RIPInterface{}
+	var protocol string
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if strings.HasPrefix(line, "BIRD") ||
+			strings.HasPrefix(line, "Access restricted") ||
+			strings.HasPrefix(line, "Interface") {
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(\S+):$`).FindStringSubmatch(line); m != nil {
+			protocol = m[1]
+			continue
+		}
+
+		m := regexp.MustCompile(`^(\S+)\s+(Up|Down)\s+(\d+)\s+(\d+)\s+([\d.]+)$`).FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		iface := RIPInterface{
+			Protocol:  protocol,
+			Interface: m[1],
+			State:     m[2],
+			Metric:    atoi(m[3]),
+			Neighbors: atoi(m[4]),
+		}
+		iface.Timer, _ = strconv.ParseFloat(m[5], 64)
+
+		interfaces = append(interfaces, iface)
+	}
+
+	return interfaces
+}
+
+func ParseRIPNeighbors(data string) []RIPNeighbor {
+	neighbors := []RIPNeighbor{}
+	var protocol string
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if strings.HasPrefix(line, "BIRD") ||
+			strings.HasPrefix(line, "Access restricted") ||
+			strings.HasPrefix(line, "IP address") {
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(\S+):$`).FindStringSubmatch(line); m != nil {
+			protocol = m[1]
+			continue
+		}
+
+		m := regexp.MustCompile(`^([0-9a-fA-F.:]+)\s+(\S+)\s+(\d+)\s+(\d+)\s+([\d.]+)$`).FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+
+		neighbor := RIPNeighbor{
+			Protocol:  protocol,
+			Address:   m[1],
+			Interface: m[2],
+			Metric:    atoi(m[3]),
+			Routes:    atoi(m[4]),
+		}
+		neighbor.Seen, _ = strconv.ParseFloat(m[5], 64)
+
+		neighbors = append(neighbors, neighbor)
+	}
+
+	return neighbors
+}
@@ -0,0 +1,19 @@
+package birdparse
+
+type RIPInterface struct {
+	Protocol  string  `json:"protocol"`
+	Interface string  `json:"interface"`
+	State     string  `json:"state"`
+	Metric    int     `json:"metric"`
+	Neighbors int     `json:"neighbors"`
+	Timer     float64 `json:"timer"`
+}
+
+type RIPNeighbor struct {
+	Protocol  string  `json:"protocol"`
+	Address   string  `json:"address"`
+	Interface string  `json:"interface"`
+	Metric    int     `json:"metric"`
+	Routes    int     `json:"routes"`
+	Seen      float64 `json:"seen"`
+}
@@ -0,0 +1,61 @@
+package birdparse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRIPInterfaces(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+rip1:
+Interface  State  Metric   Nbrs   Timer
+eth0       Up          1      2  14.210
+eth1       Down        3      0   0.000`
+
+	expected := []RIPInterface{
+		{
+			Protocol:  "rip1",
+			Interface: "eth0",
+			State:     "Up",
+			Metric:    1,
+			Neighbors: 2,
+			Timer:     14.21,
+		},
+		{
+			Protocol:  "rip1",
+			Interface: "eth1",
+			State:     "Down",
+			Metric:    3,
+		},
+	}
+
+	result := ParseRIPInterfaces(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseRIPInterfaces() = %+v, want %+v", result, expected)
+	}
+}
+
+func TestParseRIPNeighbors(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+rip1:
+IP address                Interface  Metric Routes    Seen
+10.151.104.2              eth0            1      7   3.104`
+
+	expected := []RIPNeighbor{
+		{
+			Protocol:  "rip1",
+			Address:   "10.151.104.2",
+			Interface: "eth0",
+			Metric:    1,
+			Routes:    7,
+			Seen:      3.104,
+		},
+	}
+
+	result := ParseRIPNeighbors(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseRIPNeighbors() = %+v, want %+v", result, expected)
+	}
+}
@@ -1,6 +1,8 @@
 package birdparse
 
 import (
+	"fmt"
+	"net/netip"
 	"regexp"
 	"strconv"
 	"strings"
@@ -10,6 +12,7 @@ type collectorType int
 
 const (
 	collectorNone collectorType = iota
+	collectorAttribute
 	collectorGateway
 	collectorTypeSource
 	collectorBGPPrefix
@@ -21,8 +24,18 @@ const (
 	collectorBGPAggregator
 	collectorBGPCommunity
 	collectorBGPLargeCommunity
+	collectorBGPOTC
 	collectorOSPFMetric1
+	collectorOSPFMetric2
+	collectorOSPFTag
 	collectorOSPFRouterID
+	collectorBabelMetric
+	collectorBabelSeqno
+	collectorBabelRouterID
+	collectorRIPMetric
+	collectorRIPTag
+	collectorKernelSource
+	collectorKernelMetric
 )
 
 func ParseRoutes(data string) []Route {
@@ -46,15 +59,33 @@ func ParseRoutes(data string) []Route {
 		fullLine := strings.Join(collectorLines, "")
 		fullLine = strings.TrimSpace(fullLine)
 
+		if currentCollector != collectorGateway {
+			if matches := regexp.MustCompile(`^([A-Za-z][\w.\- \[\]]*):(.*)$`).FindStringSubmatch(fullLine); matches != nil {
+				currentRoute.Attributes = append(currentRoute.Attributes, RouteAttribute{
+					Name:  matches[1],
+					Value: strings.TrimSpace(matches[2]),
+				})
+			}
+		}
+
 		switch currentCollector {
 		case collectorGateway:
-			if matches := regexp.MustCompile(`^via\s+([0-9a-f\.\:]+)\s+on\s+([a-zA-Z0-9_\.\-\/]+).*$`).FindStringSubmatch(fullLine); matches != nil {
+			if matches := regexp.MustCompile(`^via\s+([0-9a-f\.\:]+)\s+on\s+([a-zA-Z0-9_\.\-\/]+)(?:\s+mpls\s+([\d/]+))?.*$`).FindStringSubmatch(fullLine); matches != nil {
 				currentRoute.Gateway = matches[1]
 				currentRoute.Interface = matches[2]
+				if matches[3] != "" {
+					currentRoute.MPLSLabels = parseMPLSLabels(matches[3])
+				}
 			}
 		case collectorTypeSource:
 			if matches := regexp.MustCompile(`^(?:Type|source):\s+(.*)$`).FindStringSubmatch(fullLine); matches != nil {
 				currentRoute.Type = strings.Fields(strings.TrimSpace(matches[1]))
+				if len(currentRoute.Type) > 0 && strings.HasPrefix(currentRoute.Type[0], "OSPF") {
+					if currentRoute.OSPF == nil {
+						currentRoute.OSPF = &RouteOSPFInfo{}
+					}
+					currentRoute.OSPF.RouteType = OSPFRouteType(currentRoute.Type[0])
+				}
 			}
 		case collectorBGPCommunity:
 			if matches := regexp.MustCompile(`^BGP\.community:\s+(.+)$`).FindStringSubmatch(fullLine); matches != nil {
@@ -122,6 +153,13 @@ func ParseRoutes(data string) []Route {
 				}
 				currentRoute.BGP.Aggregator = strings.TrimSpace(matches[1])
 			}
+		case collectorBGPOTC:
+			if matches := regexp.MustCompile(`^BGP\.otc:\s+(\d+)$`).FindStringSubmatch(fullLine); matches != nil {
+				if currentRoute.BGP == nil {
+					currentRoute.BGP = &RouteBGPInfo{}
+				}
+				currentRoute.BGP.OTC = atoi(matches[1])
+			}
 		case collectorBGPPrefix:
 			if matches := regexp.MustCompile(`^BGP\.origin:\s+(\w+)$`).FindStringSubmatch(fullLine); matches != nil {
 				if currentRoute.BGP == nil {
@@ -136,6 +174,21 @@ func ParseRoutes(data string) []Route {
 				}
 				currentRoute.OSPF.Metric1 = atoi(matches[1])
 			}
+		case collectorOSPFMetric2:
+			if matches := regexp.MustCompile(`^OSPF\.metric2:\s+(\d+)$`).FindStringSubmatch(fullLine); matches != nil {
+				if currentRoute.OSPF == nil {
+					currentRoute.OSPF = &RouteOSPFInfo{}
+				}
+				currentRoute.OSPF.Metric2 = atoi(matches[1])
+			}
+		case collectorOSPFTag:
+			if matches := regexp.MustCompile(`^OSPF\.tag:\s+(0x[0-9a-fA-F]+|\d+)$`).FindStringSubmatch(fullLine); matches != nil {
+				if currentRoute.OSPF == nil {
+					currentRoute.OSPF = &RouteOSPFInfo{}
+				}
+				tag, _ := strconv.ParseInt(matches[1], 0, 64)
+				currentRoute.OSPF.Tag = int(tag)
+			}
 		case collectorOSPFRouterID:
 			if matches := regexp.MustCompile(`^OSPF\.router_id:(.*)$`).FindStringSubmatch(fullLine); matches != nil {
 				if currentRoute.OSPF == nil {
@@ -143,6 +196,56 @@ func ParseRoutes(data string) []Route {
 				}
 				currentRoute.OSPF.RouterID = strings.TrimSpace(matches[1])
 			}
+		case collectorBabelMetric:
+			if matches := regexp.MustCompile(`^Babel\.metric:\s+(\d+)$`).FindStringSubmatch(fullLine); matches != nil {
+				if currentRoute.Babel == nil {
+					currentRoute.Babel = &RouteBabelInfo{}
+				}
+				currentRoute.Babel.Metric = atoi(matches[1])
+			}
+		case collectorBabelSeqno:
+			if matches := regexp.MustCompile(`^Babel\.seqno:\s+(\d+)$`).FindStringSubmatch(fullLine); matches != nil {
+				if currentRoute.Babel == nil {
+					currentRoute.Babel = &RouteBabelInfo{}
+				}
+				currentRoute.Babel.Seqno = atoi(matches[1])
+			}
+		case collectorBabelRouterID:
+			if matches := regexp.MustCompile(`^Babel\.router_id:(.*)$`).FindStringSubmatch(fullLine); matches != nil {
+				if currentRoute.Babel == nil {
+					currentRoute.Babel = &RouteBabelInfo{}
+				}
+				currentRoute.Babel.RouterID = strings.TrimSpace(matches[1])
+			}
+		case collectorRIPMetric:
+			if matches := regexp.MustCompile(`^RIP\.metric:\s+(\d+)$`).FindStringSubmatch(fullLine); matches != nil {
+				if currentRoute.RIP == nil {
+					currentRoute.RIP = &RouteRIPInfo{}
+				}
+				currentRoute.RIP.Metric = atoi(matches[1])
+			}
+		case collectorRIPTag:
+			if matches := regexp.MustCompile(`^RIP\.tag:\s+(?:0x)?([0-9a-fA-F]+)$`).FindStringSubmatch(fullLine); matches != nil {
+				if currentRoute.RIP == nil {
+					currentRoute.RIP = &RouteRIPInfo{}
+				}
+				tag, _ := strconv.ParseInt(matches[1], 16, 64)
+				currentRoute.RIP.Tag = int(tag)
+			}
+		case collectorKernelSource:
+			if matches := regexp.MustCompile(`^Kernel\.source:\s+(\d+)$`).FindStringSubmatch(fullLine); matches != nil {
+				if currentRoute.Kernel == nil {
+					currentRoute.Kernel = &RouteKernelInfo{}
+				}
+				currentRoute.Kernel.Source = atoi(matches[1])
+			}
+		case collectorKernelMetric:
+			if matches := regexp.MustCompile(`^Kernel\.metric:\s+(\d+)$`).FindStringSubmatch(fullLine); matches != nil {
+				if currentRoute.Kernel == nil {
+					currentRoute.Kernel = &RouteKernelInfo{}
+				}
+				currentRoute.Kernel.Metric = atoi(matches[1])
+			}
 		}
 
 		resetCollector()
@@ -157,7 +260,7 @@ func ParseRoutes(data string) []Route {
 			continue
 		}
 
-		if matches := regexp.MustCompile(`^([0-9a-f.:\/]+)\s+((?:via\s+([0-9a-f.:]+)\s+on\s+([a-zA-Z0-9_.\-\/]+))|\w+)\s+\[(\w+)\s+([0-9]{4}-[0-9]{1,2}-[0-9]{1,2}|[0-9]{1,2}:[0-9]{1,2}:[0-9]{1,2}(?:\.[0-9]+)?)(?:\s+from\s+([0-9a-f.:\/]+))?\](?:\s+(\*)(?:\s+I)?)?\s+\((\d+)(?:\/(\-?\d+))?\).*$`).FindStringSubmatch(line); matches != nil {
+		if matches := regexp.MustCompile(`^([0-9.]+:[0-9]+(?::[0-9]+)?\s+[0-9a-f.:\/]+|[0-9a-f.:\/]+|flow[46]\s+\{[^}]*\})\s+(?:((?:via\s+([0-9a-f.:]+)\s+on\s+([a-zA-Z0-9_.\-\/]+))|\w+)\s+)?\[(\w+)\s+([0-9]{4}-[0-9]{1,2}-[0-9]{1,2}|[0-9]{1,2}:[0-9]{1,2}:[0-9]{1,2}(?:\.[0-9]+)?)(?:\s+from\s+([0-9a-f.:\/]+))?\](?:\s+(\*))?(?:\s+(I|IA|E1|E2))?\s+\((\d+)(?:\/(\-?\d+))?(?:\/(\d+))?\).*$`).FindStringSubmatch(line); matches != nil {
 			processCollector()
 			if currentRoute.Network != "" {
 				routes = append(routes, currentRoute)
@@ -165,7 +268,7 @@ func ParseRoutes(data string) []Route {
 			currentRoute = mainRouteDetail(matches)
 			resetCollector()
 			continue
-		} else if matches := regexp.MustCompile(`^\s+((?:via\s+([0-9a-f.:]+)\s+on\s+([a-zA-Z0-9_.\-\/]+))|\w+)\s+\[(\w+)\s+([0-9]{4}-[0-9]{1,2}-[0-9]{1,2}|[0-9]{1,2}:[0-9]{1,2}:[0-9]{1,2}(?:\.[0-9]+)?)(?:\s+from\s+([0-9a-f.:\/]+))?\](?:\s+(\*))?\s+\((\d+)(?:\/(\-?\d+))?\).*$`).FindStringSubmatch(line); matches != nil {
+		} else if matches := regexp.MustCompile(`^\s+(?:((?:via\s+([0-9a-f.:]+)\s+on\s+([a-zA-Z0-9_.\-\/]+))|\w+)\s+)?\[(\w+)\s+([0-9]{4}-[0-9]{1,2}-[0-9]{1,2}|[0-9]{1,2}:[0-9]{1,2}:[0-9]{1,2}(?:\.[0-9]+)?)(?:\s+from\s+([0-9a-f.:\/]+))?\](?:\s+(\*))?(?:\s+(I|IA|E1|E2))?\s+\((\d+)(?:\/(\-?\d+))?(?:\/(\d+))?\).*$`).FindStringSubmatch(line); matches != nil {
 			processCollector()
 			if currentRoute.Network != "" {
 				routes = append(routes, currentRoute)
@@ -204,12 +307,34 @@ func ParseRoutes(data string) []Route {
 			detectedCollector = collectorBGPAtomicAggr
 		case strings.HasPrefix(trimmedLine, "BGP.aggregator:"):
 			detectedCollector = collectorBGPAggregator
+		case strings.HasPrefix(trimmedLine, "BGP.otc:"):
+			detectedCollector = collectorBGPOTC
 		case strings.HasPrefix(trimmedLine, "BGP.origin:"):
 			detectedCollector = collectorBGPPrefix
 		case strings.HasPrefix(trimmedLine, "OSPF.metric1:"):
 			detectedCollector = collectorOSPFMetric1
+		case strings.HasPrefix(trimmedLine, "OSPF.metric2:"):
+			detectedCollector = collectorOSPFMetric2
+		case strings.HasPrefix(trimmedLine, "OSPF.tag:"):
+			detectedCollector = collectorOSPFTag
 		case strings.HasPrefix(trimmedLine, "OSPF.router_id:"):
 			detectedCollector = collectorOSPFRouterID
+		case strings.HasPrefix(trimmedLine, "Babel.metric:"):
+			detectedCollector = collectorBabelMetric
+		case strings.HasPrefix(trimmedLine, "Babel.seqno:"):
+			detectedCollector = collectorBabelSeqno
+		case strings.HasPrefix(trimmedLine, "Babel.router_id:"):
+			detectedCollector = collectorBabelRouterID
+		case strings.HasPrefix(trimmedLine, "RIP.metric:"):
+			detectedCollector = collectorRIPMetric
+		case strings.HasPrefix(trimmedLine, "RIP.tag:"):
+			detectedCollector = collectorRIPTag
+		case strings.HasPrefix(trimmedLine, "Kernel.source:"):
+			detectedCollector = collectorKernelSource
+		case strings.HasPrefix(trimmedLine, "Kernel.metric:"):
+			detectedCollector = collectorKernelMetric
+		case regexp.MustCompile(`^[A-Za-z][\w.\- \[\]]*:(\s|$)`).MatchString(trimmedLine):
+			detectedCollector = collectorAttribute
 		default:
 			if currentCollector != collectorNone && trimmedLine != "" {
 				collectorLines = append(collectorLines, line)
@@ -229,9 +354,44 @@ func ParseRoutes(data string) []Route {
 		routes = append(routes, currentRoute)
 	}
 
+	for i := range routes {
+		routes[i].parseAddrs()
+	}
+
 	return routes
 }
 
+func (r *Route) parseAddrs() {
+	network := r.Network
+	if fields := strings.Fields(network); len(fields) == 2 && !strings.HasPrefix(network, "flow") {
+		network = fields[1]
+	}
+
+	if !strings.HasPrefix(network, "flow") {
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			r.Errors = append(r.Errors, fmt.Errorf("network %q: %w", network, err))
+		}
+		r.NetworkPrefix = prefix
+	}
+
+	if strings.ContainsAny(r.Gateway, ".:") {
+		addr, err := netip.ParseAddr(r.Gateway)
+		if err != nil {
+			r.Errors = append(r.Errors, fmt.Errorf("gateway %q: %w", r.Gateway, err))
+		}
+		r.GatewayAddr = addr
+	}
+
+	if r.FromAddress != "" {
+		addr, err := netip.ParseAddr(r.FromAddress)
+		if err != nil {
+			r.Errors = append(r.Errors, fmt.Errorf("from address %q: %w", r.FromAddress, err))
+		}
+		r.FromAddr = addr
+	}
+}
+
 func mainRouteDetail(matches []string) Route {
 	var r Route
 	if len(matches) < 9 {
@@ -257,6 +417,10 @@ func mainRouteDetail(matches []string) Route {
 		r.FromProtocol = matches[5]
 	}
 
+	if len(matches) >= 7 && matches[6] != "" {
+		r.Since = matches[6]
+	}
+
 	if len(matches) >= 8 && matches[7] != "" {
 		r.FromAddress = matches[7]
 	}
@@ -266,17 +430,27 @@ func mainRouteDetail(matches []string) Route {
 	}
 
 	if len(matches) >= 10 && matches[9] != "" {
-		if metric, err := strconv.Atoi(matches[9]); err == nil {
-			r.Metric = metric
+		r.OSPF = &RouteOSPFInfo{
+			RouteType: ospfRouteTypeFromCode(matches[9]),
 		}
 	}
 
 	if len(matches) >= 11 && matches[10] != "" {
-		if igpMetric, err := strconv.Atoi(matches[10]); err == nil {
+		if metric, err := strconv.Atoi(matches[10]); err == nil {
+			r.Metric = metric
+		}
+	}
+
+	if len(matches) >= 12 && matches[11] != "" {
+		if igpMetric, err := strconv.Atoi(matches[11]); err == nil {
 			r.IGPMetric = igpMetric
 		}
 	}
 
+	if len(matches) >= 13 && matches[12] != "" && r.OSPF != nil {
+		r.OSPF.Metric2 = atoi(matches[12])
+	}
+
 	return r
 }
 
@@ -356,3 +530,30 @@ func parseASPath(asPathStr string) ([]int, error) {
 
 	return asPath, nil
 }
+
+func ospfRouteTypeFromCode(code string) OSPFRouteType {
+	switch code {
+	case "I":
+		return OSPFRouteTypeIntraArea
+	case "IA":
+		return OSPFRouteTypeInterArea
+	case "E1":
+		return OSPFRouteTypeExternal1
+	case "E2":
+		return OSPFRouteTypeExternal2
+	}
+
+	return ""
+}
+
+func parseMPLSLabels(labelStr string) []int {
+	labels := []int{}
+
+	for _, label := range strings.FieldsFunc(labelStr, func(r rune) bool { return r == '/' || r == ' ' }) {
+		if value, err := strconv.Atoi(label); err == nil {
+			labels = append(labels, value)
+		}
+	}
+
+	return labels
+}
@@ -0,0 +1,207 @@
+package birdparse
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func DiffRoutes(old, new []Route) RouteDiff {
+	var diff RouteDiff
+
+	oldPaths, oldBest, oldOrder := indexRoutePaths(old)
+	newPaths, newBest, newOrder := indexRoutePaths(new)
+
+	oldPrefixes := make(map[string]bool)
+	for _, route := range old {
+		oldPrefixes[route.Network] = true
+	}
+
+	newPrefixes := make(map[string]bool)
+	for _, route := range new {
+		newPrefixes[route.Network] = true
+	}
+
+	added := make(map[string]bool)
+	unmatched := make(map[string][]Route)
+	for _, key := range newOrder {
+		paths := newPaths[key]
+		network := paths[0].Network
+
+		if !oldPrefixes[network] && !added[network] {
+			added[network] = true
+			diff.AddedPrefixes = append(diff.AddedPrefixes, network)
+		}
+
+		pairs, addedPaths, withdrawnPaths := pairRoutePaths(oldPaths[key], paths)
+		unmatched[key] = withdrawnPaths
+		diff.AddedPaths = append(diff.AddedPaths, addedPaths...)
+
+		for _, pair := range pairs {
+			previous, route := pair[0], pair[1]
+
+			if changes := diffRouteAttributes(previous, route); len(changes) > 0 {
+				diff.PathChanges = append(diff.PathChanges, RoutePathChange{
+					Network:      route.Network,
+					FromProtocol: route.FromProtocol,
+					Old:          previous,
+					New:          route,
+					Changes:      changes,
+				})
+			}
+		}
+	}
+
+	withdrawn := make(map[string]bool)
+	for _, key := range oldOrder {
+		paths := oldPaths[key]
+		network := paths[0].Network
+
+		if !newPrefixes[network] && !withdrawn[network] {
+			withdrawn[network] = true
+			diff.WithdrawnPrefixes = append(diff.WithdrawnPrefixes, network)
+		}
+
+		if remaining, ok := unmatched[key]; ok {
+			paths = remaining
+		}
+		diff.WithdrawnPaths = append(diff.WithdrawnPaths, paths...)
+	}
+
+	var networks []string
+	seen := make(map[string]bool)
+	for _, route := range append(append([]Route{}, new...), old...) {
+		if oldPrefixes[route.Network] && newPrefixes[route.Network] && !seen[route.Network] {
+			seen[route.Network] = true
+			networks = append(networks, route.Network)
+		}
+	}
+
+	for _, network := range networks {
+		if oldBest[network] != newBest[network] {
+			diff.BestPathChanges = append(diff.BestPathChanges, RouteBestPathChange{
+				Network:     network,
+				OldProtocol: oldBest[network],
+				NewProtocol: newBest[network],
+			})
+		}
+	}
+
+	return diff
+}
+
+func indexRoutePaths(routes []Route) (map[string][]Route, map[string]string, []string) {
+	paths := make(map[string][]Route, len(routes))
+	best := make(map[string]string)
+	order := make([]string, 0, len(routes))
+
+	for _, route := range routes {
+		key := strings.Join([]string{route.Network, route.FromProtocol, route.FromAddress}, "\x00")
+		if _, ok := paths[key]; !ok {
+			order = append(order, key)
+		}
+		paths[key] = append(paths[key], route)
+
+		if route.Primary {
+			best[route.Network] = route.FromProtocol
+		}
+	}
+
+	return paths, best, order
+}
+
+func pairRoutePaths(old, new []Route) ([][2]Route, []Route, []Route) {
+	var pairs [][2]Route
+	var added []Route
+
+	used := make([]bool, len(old))
+	matched := make([]bool, len(new))
+
+	for i, route := range new {
+		for j, previous := range old {
+			if !used[j] && previous.Gateway == route.Gateway {
+				pairs = append(pairs, [2]Route{previous, route})
+				used[j], matched[i] = true, true
+				break
+			}
+		}
+	}
+
+	for i, route := range new {
+		if matched[i] {
+			continue
+		}
+
+		for j, previous := range old {
+			if !used[j] {
+				pairs = append(pairs, [2]Route{previous, route})
+				used[j], matched[i] = true, true
+				break
+			}
+		}
+
+		if !matched[i] {
+			added = append(added, route)
+		}
+	}
+
+	var withdrawn []Route
+	for j, previous := range old {
+		if !used[j] {
+			withdrawn = append(withdrawn, previous)
+		}
+	}
+
+	return pairs, added, withdrawn
+}
+
+func diffRouteAttributes(old, new Route) []RouteAttributeChange {
+	var changes []RouteAttributeChange
+
+	compare := func(attribute, oldValue, newValue string) {
+		if oldValue != newValue {
+			changes = append(changes, RouteAttributeChange{
+				Attribute: attribute,
+				Old:       oldValue,
+				New:       newValue,
+			})
+		}
+	}
+
+	compare("gateway", old.Gateway, new.Gateway)
+
+	oldBGP, newBGP := old.BGP, new.BGP
+	if oldBGP == nil {
+		oldBGP = &RouteBGPInfo{}
+	}
+	if newBGP == nil {
+		newBGP = &RouteBGPInfo{}
+	}
+
+	compare("origin", oldBGP.Origin, newBGP.Origin)
+	compare("as_path", formatInts(oldBGP.ASPath), formatInts(newBGP.ASPath))
+	compare("next_hop", strings.Join(oldBGP.NextHop, " "), strings.Join(newBGP.NextHop, " "))
+	compare("local_pref", fmt.Sprint(oldBGP.LocalPref), fmt.Sprint(newBGP.LocalPref))
+	compare("med", fmt.Sprint(oldBGP.MED), fmt.Sprint(newBGP.MED))
+	compare("communities", formatCommunitySet(oldBGP.Communities), formatCommunitySet(newBGP.Communities))
+	compare("large_communities", formatCommunitySet(oldBGP.LargeCommunities), formatCommunitySet(newBGP.LargeCommunities))
+
+	return changes
+}
+
+func formatInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = fmt.Sprint(value)
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatCommunitySet(communities [][]int) string {
+	parts := make([]string, len(communities))
+	for i, community := range communities {
+		parts[i] = "(" + strings.ReplaceAll(formatInts(community), " ", ",") + ")"
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, " ")
+}
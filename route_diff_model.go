@@ -0,0 +1,39 @@
+package birdparse
+
+type RouteAttributeChange struct {
+	Attribute string `json:"attribute"`
+	Old       string `json:"old"`
+	New       string `json:"new"`
+}
+
+type RoutePathChange struct {
+	Network      string                 `json:"network"`
+	FromProtocol string                 `json:"from_protocol"`
+	Old          Route                  `json:"old"`
+	New          Route                  `json:"new"`
+	Changes      []RouteAttributeChange `json:"changes"`
+}
+
+type RouteBestPathChange struct {
+	Network     string `json:"network"`
+	OldProtocol string `json:"old_protocol"`
+	NewProtocol string `json:"new_protocol"`
+}
+
+type RouteDiff struct {
+	AddedPrefixes     []string              `json:"added_prefixes"`
+	WithdrawnPrefixes []string              `json:"withdrawn_prefixes"`
+	AddedPaths        []Route               `json:"added_paths"`
+	WithdrawnPaths    []Route               `json:"withdrawn_paths"`
+	BestPathChanges   []RouteBestPathChange `json:"best_path_changes"`
+	PathChanges       []RoutePathChange     `json:"path_changes"`
+}
+
+func (d RouteDiff) IsEmpty() bool {
+	return len(d.AddedPrefixes) == 0 &&
+		len(d.WithdrawnPrefixes) == 0 &&
+		len(d.AddedPaths) == 0 &&
+		len(d.WithdrawnPaths) == 0 &&
+		len(d.BestPathChanges) == 0 &&
+		len(d.PathChanges) == 0
+}
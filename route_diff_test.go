@@ -0,0 +1,198 @@
+package birdparse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffRoutes(t *testing.T) {
+	before := `BIRD 2.17.1 ready.
+Table master4:
+40.0.0.0/14          unicast [peer_a 2026-01-19] * (100) [AS4249i]
+        via 10.151.104.1 on eth0
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 44324 4249
+        BGP.next_hop: 10.151.104.1
+        BGP.local_pref: 100
+        BGP.community: (2914,410) (32787,65522)
+                     unicast [peer_b 2026-01-19] (100) [AS4249i]
+        via 10.151.104.2 on eth0
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 1234 4249
+        BGP.next_hop: 10.151.104.2
+        BGP.local_pref: 100
+192.0.2.0/24         unicast [peer_a 2026-01-19] * (100) [AS64500i]
+        via 10.151.104.1 on eth0
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 44324 64500
+        BGP.next_hop: 10.151.104.1
+        BGP.local_pref: 100`
+
+	after := `BIRD 2.17.1 ready.
+Table master4:
+40.0.0.0/14          unicast [peer_b 2026-01-19] * (100) [AS4249i]
+        via 10.151.104.2 on eth0
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 1234 4249
+        BGP.next_hop: 10.151.104.2
+        BGP.local_pref: 200
+                     unicast [peer_a 2026-01-19] (100) [AS4249i]
+        via 10.151.104.1 on eth0
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 44324 4249
+        BGP.next_hop: 10.151.104.1
+        BGP.local_pref: 100
+        BGP.community: (32787,65522) (2914,410)
+198.51.100.0/24      unicast [peer_a 2026-01-19] * (100) [AS64501i]
+        via 10.151.104.1 on eth0
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 44324 64501
+        BGP.next_hop: 10.151.104.1
+        BGP.local_pref: 100`
+
+	diff := DiffRoutes(ParseRoutes(before), ParseRoutes(after))
+
+	if !reflect.DeepEqual(diff.AddedPrefixes, []string{"198.51.100.0/24"}) {
+		t.Errorf("AddedPrefixes = %v", diff.AddedPrefixes)
+	}
+
+	if !reflect.DeepEqual(diff.WithdrawnPrefixes, []string{"192.0.2.0/24"}) {
+		t.Errorf("WithdrawnPrefixes = %v", diff.WithdrawnPrefixes)
+	}
+
+	if len(diff.AddedPaths) != 1 || diff.AddedPaths[0].Network != "198.51.100.0/24" {
+		t.Errorf("AddedPaths = %v", diff.AddedPaths)
+	}
+
+	if len(diff.WithdrawnPaths) != 1 || diff.WithdrawnPaths[0].Network != "192.0.2.0/24" {
+		t.Errorf("WithdrawnPaths = %v", diff.WithdrawnPaths)
+	}
+
+	expectedBest := []RouteBestPathChange{
+		{Network: "40.0.0.0/14", OldProtocol: "peer_a", NewProtocol: "peer_b"},
+	}
+	if !reflect.DeepEqual(diff.BestPathChanges, expectedBest) {
+		t.Errorf("BestPathChanges = %v, want %v", diff.BestPathChanges, expectedBest)
+	}
+
+	if len(diff.PathChanges) != 1 {
+		t.Fatalf("PathChanges = %v, want 1 change", diff.PathChanges)
+	}
+
+	change := diff.PathChanges[0]
+	if change.Network != "40.0.0.0/14" || change.FromProtocol != "peer_b" {
+		t.Errorf("PathChanges[0] = %s %s", change.Network, change.FromProtocol)
+	}
+
+	expectedChanges := []RouteAttributeChange{
+		{Attribute: "local_pref", Old: "100", New: "200"},
+	}
+	if !reflect.DeepEqual(change.Changes, expectedChanges) {
+		t.Errorf("Changes = %v, want %v", change.Changes, expectedChanges)
+	}
+}
+
+func TestDiffRoutesUnchanged(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+Table master4:
+10.0.0.0/8           unicast [static4 2026-01-16] * (200)
+        via 10.151.104.1 on eth0`
+
+	diff := DiffRoutes(ParseRoutes(data), ParseRoutes(data))
+	if !diff.IsEmpty() {
+		t.Errorf("DiffRoutes() = %+v, want empty diff", diff)
+	}
+}
+
+func TestDiffRoutesAddPath(t *testing.T) {
+	before := `BIRD 2.17.1 ready.
+Table master4:
+192.0.2.0/24         unicast [rs1 2026-01-19] * (100) [AS64500i]
+        via 10.151.104.1 on eth0
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 64500
+        BGP.next_hop: 10.151.104.1
+        BGP.local_pref: 100
+                     unicast [rs1 2026-01-19] (100) [AS64501i]
+        via 10.151.104.2 on eth0
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 64501
+        BGP.next_hop: 10.151.104.2
+        BGP.local_pref: 100`
+
+	after := `BIRD 2.17.1 ready.
+Table master4:
+192.0.2.0/24         unicast [rs1 2026-01-19] * (100) [AS64500i]
+        via 10.151.104.1 on eth0
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 64500
+        BGP.next_hop: 10.151.104.1
+        BGP.local_pref: 100
+                     unicast [rs1 2026-01-19] (100) [AS64501i]
+        via 10.151.104.2 on eth0
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 64501
+        BGP.next_hop: 10.151.104.2
+        BGP.local_pref: 50`
+
+	diff := DiffRoutes(ParseRoutes(before), ParseRoutes(after))
+
+	if len(diff.AddedPaths) != 0 || len(diff.WithdrawnPaths) != 0 {
+		t.Errorf("AddedPaths = %v, WithdrawnPaths = %v, want none", diff.AddedPaths, diff.WithdrawnPaths)
+	}
+
+	if len(diff.PathChanges) != 1 {
+		t.Fatalf("PathChanges = %v, want 1 change", diff.PathChanges)
+	}
+
+	change := diff.PathChanges[0]
+	if change.New.Gateway != "10.151.104.2" {
+		t.Errorf("PathChanges[0].New.Gateway = %q, want %q", change.New.Gateway, "10.151.104.2")
+	}
+
+	expectedChanges := []RouteAttributeChange{
+		{Attribute: "local_pref", Old: "100", New: "50"},
+	}
+	if !reflect.DeepEqual(change.Changes, expectedChanges) {
+		t.Errorf("Changes = %v, want %v", change.Changes, expectedChanges)
+	}
+}
+
+func TestDiffRoutesGatewayChange(t *testing.T) {
+	before := `BIRD 2.17.1 ready.
+Table master4:
+10.0.0.0/8           unicast [static4 2026-01-16] * (200)
+        via 10.151.104.1 on eth0`
+
+	after := `BIRD 2.17.1 ready.
+Table master4:
+10.0.0.0/8           unicast [static4 2026-01-16] * (200)
+        via 10.151.104.2 on eth0`
+
+	diff := DiffRoutes(ParseRoutes(before), ParseRoutes(after))
+
+	if len(diff.AddedPaths) != 0 || len(diff.WithdrawnPaths) != 0 {
+		t.Errorf("AddedPaths = %v, WithdrawnPaths = %v, want none", diff.AddedPaths, diff.WithdrawnPaths)
+	}
+
+	if len(diff.PathChanges) != 1 {
+		t.Fatalf("PathChanges = %v, want 1 change", diff.PathChanges)
+	}
+
+	expectedChanges := []RouteAttributeChange{
+		{Attribute: "gateway", Old: "10.151.104.1", New: "10.151.104.2"},
+	}
+	if !reflect.DeepEqual(diff.PathChanges[0].Changes, expectedChanges) {
+		t.Errorf("Changes = %v, want %v", diff.PathChanges[0].Changes, expectedChanges)
+	}
+}
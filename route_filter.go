@@ -0,0 +1,706 @@
+package birdparse
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+type filterTokenKind int
+
+const (
+	filterTokenEOF filterTokenKind = iota
+	filterTokenWord
+	filterTokenString
+	filterTokenSymbol
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	pos  int
+}
+
+type filterFieldKind int
+
+const (
+	filterFieldInt filterFieldKind = iota
+	filterFieldString
+	filterFieldList
+	filterFieldBool
+	filterFieldPath
+	filterFieldCommunity
+)
+
+type filterField struct {
+	kind      filterFieldKind
+	size      int
+	intValue  func(route Route) (int, bool)
+	strValue  func(route Route) (string, bool)
+	listValue func(route Route) []string
+	boolValue func(route Route) bool
+	pathValue func(route Route) ([]int, bool)
+	commValue func(route Route) [][]int
+}
+
+var filterFields = map[string]filterField{
+	"network":       {kind: filterFieldString, strValue: func(r Route) (string, bool) { return r.Network, r.Network != "" }},
+	"net":           {kind: filterFieldString, strValue: func(r Route) (string, bool) { return r.Network, r.Network != "" }},
+	"gateway":       {kind: filterFieldString, strValue: func(r Route) (string, bool) { return r.Gateway, r.Gateway != "" }},
+	"interface":     {kind: filterFieldString, strValue: func(r Route) (string, bool) { return r.Interface, r.Interface != "" }},
+	"from_protocol": {kind: filterFieldString, strValue: func(r Route) (string, bool) { return r.FromProtocol, r.FromProtocol != "" }},
+	"proto":         {kind: filterFieldString, strValue: func(r Route) (string, bool) { return r.FromProtocol, r.FromProtocol != "" }},
+	"from_address":  {kind: filterFieldString, strValue: func(r Route) (string, bool) { return r.FromAddress, r.FromAddress != "" }},
+	"primary":       {kind: filterFieldBool, boolValue: func(r Route) bool { return r.Primary }},
+	"metric":        {kind: filterFieldInt, intValue: func(r Route) (int, bool) { return r.Metric, true }},
+	"igp_metric":    {kind: filterFieldInt, intValue: func(r Route) (int, bool) { return r.IGPMetric, true }},
+	"type":          {kind: filterFieldList, listValue: func(r Route) []string { return r.Type }},
+	"origin": {kind: filterFieldString, strValue: func(r Route) (string, bool) {
+		if r.BGP == nil {
+			return "", false
+		}
+		return r.BGP.Origin, true
+	}},
+	"next_hop": {kind: filterFieldList, listValue: func(r Route) []string {
+		if r.BGP == nil {
+			return nil
+		}
+		return r.BGP.NextHop
+	}},
+	"local_pref": {kind: filterFieldInt, intValue: func(r Route) (int, bool) {
+		if r.BGP == nil {
+			return 0, false
+		}
+		return r.BGP.LocalPref, true
+	}},
+	"med": {kind: filterFieldInt, intValue: func(r Route) (int, bool) {
+		if r.BGP == nil {
+			return 0, false
+		}
+		return r.BGP.MED, true
+	}},
+	"otc": {kind: filterFieldInt, intValue: func(r Route) (int, bool) {
+		if r.BGP == nil || r.BGP.OTC == 0 {
+			return 0, false
+		}
+		return r.BGP.OTC, true
+	}},
+	"as_path": {kind: filterFieldPath, pathValue: func(r Route) ([]int, bool) {
+		if r.BGP == nil {
+			return nil, false
+		}
+		return r.BGP.ASPath, true
+	}},
+	"as_path_len": {kind: filterFieldInt, intValue: func(r Route) (int, bool) {
+		if r.BGP == nil {
+			return 0, false
+		}
+		return len(r.BGP.ASPath), true
+	}},
+	"first_as": {kind: filterFieldInt, intValue: func(r Route) (int, bool) {
+		if r.BGP == nil || len(r.BGP.ASPath) == 0 {
+			return 0, false
+		}
+		return r.BGP.ASPath[0], true
+	}},
+	"origin_as": {kind: filterFieldInt, intValue: func(r Route) (int, bool) {
+		if r.BGP == nil || len(r.BGP.ASPath) == 0 {
+			return 0, false
+		}
+		return r.BGP.ASPath[len(r.BGP.ASPath)-1], true
+	}},
+	"community": {kind: filterFieldCommunity, size: 2, commValue: func(r Route) [][]int {
+		if r.BGP == nil {
+			return nil
+		}
+		return r.BGP.Communities
+	}},
+	"large_community": {kind: filterFieldCommunity, size: 3, commValue: func(r Route) [][]int {
+		if r.BGP == nil {
+			return nil
+		}
+		return r.BGP.LargeCommunities
+	}},
+}
+
+func CompileRouteFilter(expr string) (*RouteFilter, error) {
+	tokens, err := tokenizeRouteFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens}
+
+	match, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != filterTokenEOF {
+		return nil, &RouteFilterError{Position: tok.pos, Message: "unexpected " + strconv.Quote(tok.text)}
+	}
+
+	return &RouteFilter{Expression: expr, match: match}, nil
+}
+
+func FilterRoutes(routes []Route, expr string) ([]Route, error) {
+	filter, err := CompileRouteFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return filter.Filter(routes), nil
+}
+
+func tokenizeRouteFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+
+	for i := 0; i < len(expr); {
+		c := expr[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"':
+			var b strings.Builder
+			start := i
+			i++
+			for i < len(expr) && expr[i] != '"' {
+				if expr[i] == '\\' && i+1 < len(expr) {
+					i++
+				}
+				b.WriteByte(expr[i])
+				i++
+			}
+			if i >= len(expr) {
+				return nil, &RouteFilterError{Position: start, Message: "unterminated string"}
+			}
+			i++
+			tokens = append(tokens, filterToken{kind: filterTokenString, text: b.String(), pos: start})
+		case isFilterWordChar(c):
+			start := i
+			for i < len(expr) && isFilterWordChar(expr[i]) {
+				i++
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenWord, text: expr[start:i], pos: start})
+		default:
+			if i+1 < len(expr) {
+				switch two := expr[i : i+2]; two {
+				case "&&", "||", "==", "!=", "!~", "<=", ">=":
+					tokens = append(tokens, filterToken{kind: filterTokenSymbol, text: two, pos: i})
+					i += 2
+					continue
+				}
+			}
+
+			if !strings.ContainsRune("()[],*?=~<>!", rune(c)) {
+				return nil, &RouteFilterError{Position: i, Message: "unexpected character " + strconv.QuoteRune(rune(c))}
+			}
+
+			tokens = append(tokens, filterToken{kind: filterTokenSymbol, text: string(c), pos: i})
+			i++
+		}
+	}
+
+	return append(tokens, filterToken{kind: filterTokenEOF, pos: len(expr)}), nil
+}
+
+func isFilterWordChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '.' || c == ':' || c == '/' || c == '-'
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() filterToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != filterTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *filterParser) accept(symbol string) bool {
+	if tok := p.peek(); tok.kind == filterTokenSymbol && tok.text == symbol {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) expect(symbol string) error {
+	if tok := p.peek(); !p.accept(symbol) {
+		return &RouteFilterError{Position: tok.pos, Message: "expected " + strconv.Quote(symbol)}
+	}
+	return nil
+}
+
+func (p *filterParser) parseOr() (func(Route) bool, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		l := left
+		left = func(r Route) bool { return l(r) || right(r) }
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (func(Route) bool, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		l := left
+		left = func(r Route) bool { return l(r) && right(r) }
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (func(Route) bool, error) {
+	if p.accept("!") {
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(r Route) bool { return !inner(r) }, nil
+	}
+
+	if p.accept("(") {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (func(Route) bool, error) {
+	tok := p.next()
+	if tok.kind != filterTokenWord {
+		return nil, &RouteFilterError{Position: tok.pos, Message: "expected field name"}
+	}
+
+	field, ok := filterFields[tok.text]
+	if !ok {
+		return nil, &RouteFilterError{Position: tok.pos, Message: "unknown field " + strconv.Quote(tok.text)}
+	}
+
+	opTok := p.peek()
+	op := opTok.text
+	switch {
+	case opTok.kind != filterTokenSymbol:
+		op = ""
+	case op == "==":
+		op = "="
+	}
+
+	switch op {
+	case "=", "!=", "<", "<=", ">", ">=", "~", "!~":
+		p.next()
+	default:
+		if field.kind == filterFieldBool {
+			return field.boolValue, nil
+		}
+		return nil, &RouteFilterError{Position: opTok.pos, Message: "expected operator after " + strconv.Quote(tok.text)}
+	}
+
+	var (
+		match func(Route) bool
+		err   error
+	)
+
+	switch field.kind {
+	case filterFieldInt:
+		match, err = p.parseIntComparison(field, op, opTok.pos)
+	case filterFieldString:
+		match, err = p.parseStringComparison(field, op, opTok.pos)
+	case filterFieldList:
+		match, err = p.parseListComparison(field, op, opTok.pos)
+	case filterFieldBool:
+		match, err = p.parseBoolComparison(field, op, opTok.pos)
+	case filterFieldPath:
+		match, err = p.parsePathComparison(field, op, opTok.pos)
+	case filterFieldCommunity:
+		match, err = p.parseCommunityComparison(field, op, opTok.pos)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if op == "!~" {
+		inner := match
+		match = func(r Route) bool { return !inner(r) }
+	}
+
+	return match, nil
+}
+
+func (p *filterParser) parseIntComparison(field filterField, op string, pos int) (func(Route) bool, error) {
+	value, err := p.parseNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	var compare func(a int) bool
+	switch op {
+	case "=":
+		compare = func(a int) bool { return a == value }
+	case "!=":
+		compare = func(a int) bool { return a != value }
+	case "<":
+		compare = func(a int) bool { return a < value }
+	case "<=":
+		compare = func(a int) bool { return a <= value }
+	case ">":
+		compare = func(a int) bool { return a > value }
+	case ">=":
+		compare = func(a int) bool { return a >= value }
+	default:
+		return nil, &RouteFilterError{Position: pos, Message: "operator " + strconv.Quote(op) + " not supported for numbers"}
+	}
+
+	return func(r Route) bool {
+		v, ok := field.intValue(r)
+		return ok && compare(v)
+	}, nil
+}
+
+func (p *filterParser) parseStringComparison(field filterField, op string, pos int) (func(Route) bool, error) {
+	value, err := p.parseString()
+	if err != nil {
+		return nil, err
+	}
+
+	var compare func(s string) bool
+	switch op {
+	case "=":
+		compare = func(s string) bool { return s == value }
+	case "!=":
+		compare = func(s string) bool { return s != value }
+	case "~", "!~":
+		compare = stringMatcher(value)
+	default:
+		return nil, &RouteFilterError{Position: pos, Message: "operator " + strconv.Quote(op) + " not supported for strings"}
+	}
+
+	return func(r Route) bool {
+		v, ok := field.strValue(r)
+		return ok && compare(v)
+	}, nil
+}
+
+func (p *filterParser) parseListComparison(field filterField, op string, pos int) (func(Route) bool, error) {
+	value, err := p.parseString()
+	if err != nil {
+		return nil, err
+	}
+
+	var compare func(s string) bool
+	switch op {
+	case "=", "!=":
+		compare = func(s string) bool { return s == value }
+	case "~", "!~":
+		compare = stringMatcher(value)
+	default:
+		return nil, &RouteFilterError{Position: pos, Message: "operator " + strconv.Quote(op) + " not supported for lists"}
+	}
+
+	contains := func(r Route) bool {
+		for _, v := range field.listValue(r) {
+			if compare(v) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if op == "!=" {
+		return func(r Route) bool { return !contains(r) }, nil
+	}
+
+	return contains, nil
+}
+
+func (p *filterParser) parseBoolComparison(field filterField, op string, pos int) (func(Route) bool, error) {
+	tok := p.next()
+	if tok.kind != filterTokenWord || (tok.text != "true" && tok.text != "false") {
+		return nil, &RouteFilterError{Position: tok.pos, Message: "expected true or false"}
+	}
+
+	value := tok.text == "true"
+
+	switch op {
+	case "=":
+		return func(r Route) bool { return field.boolValue(r) == value }, nil
+	case "!=":
+		return func(r Route) bool { return field.boolValue(r) != value }, nil
+	}
+
+	return nil, &RouteFilterError{Position: pos, Message: "operator " + strconv.Quote(op) + " not supported for booleans"}
+}
+
+func (p *filterParser) parsePathComparison(field filterField, op string, pos int) (func(Route) bool, error) {
+	if op != "~" && op != "!~" && op != "=" && op != "!=" {
+		return nil, &RouteFilterError{Position: pos, Message: "operator " + strconv.Quote(op) + " not supported for AS paths"}
+	}
+
+	exact := op == "=" || op == "!="
+
+	var mask []int
+
+	if p.peek().kind == filterTokenWord {
+		asn, err := p.parseNumber()
+		if err != nil {
+			return nil, err
+		}
+
+		mask = []int{-1, asn, -1}
+		if exact {
+			mask = []int{asn}
+		}
+	} else {
+		if err := p.expect("["); err != nil {
+			return nil, err
+		}
+		anchored := p.accept("=")
+
+	loop:
+		for {
+			tok := p.peek()
+
+			switch {
+			case p.accept("]"):
+				if anchored {
+					return nil, &RouteFilterError{Position: tok.pos, Message: `expected "=]" to close "[="`}
+				}
+				break loop
+			case p.accept("="):
+				if !anchored {
+					return nil, &RouteFilterError{Position: tok.pos, Message: `"=]" without opening "[="`}
+				}
+				if err := p.expect("]"); err != nil {
+					return nil, err
+				}
+				break loop
+			case p.accept("*"), p.accept("?"):
+				if exact {
+					return nil, &RouteFilterError{Position: tok.pos, Message: "AS path wildcards need ~, not " + strconv.Quote(op)}
+				}
+				if tok.text == "*" {
+					mask = append(mask, -1)
+				} else {
+					mask = append(mask, -2)
+				}
+			default:
+				asn, err := p.parseNumber()
+				if err != nil {
+					return nil, err
+				}
+				mask = append(mask, asn)
+			}
+		}
+	}
+
+	return pathMaskMatcher(field, op, mask), nil
+}
+
+func pathMaskMatcher(field filterField, op string, mask []int) func(Route) bool {
+	mask = compactPathMask(mask)
+
+	match := func(r Route) bool {
+		path, ok := field.pathValue(r)
+		return ok && matchPathMask(path, mask)
+	}
+
+	if op == "!=" {
+		return func(r Route) bool { return !match(r) }
+	}
+
+	return match
+}
+
+func (p *filterParser) parseCommunityComparison(field filterField, op string, pos int) (func(Route) bool, error) {
+	if op != "~" && op != "!~" && op != "=" && op != "!=" {
+		return nil, &RouteFilterError{Position: pos, Message: "operator " + strconv.Quote(op) + " not supported for communities"}
+	}
+
+	start := p.peek().pos
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	var pattern []int
+	for {
+		if p.accept("*") {
+			pattern = append(pattern, -1)
+		} else {
+			v, err := p.parseNumber()
+			if err != nil {
+				return nil, err
+			}
+			pattern = append(pattern, v)
+		}
+
+		if p.accept(")") {
+			break
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(pattern) != field.size {
+		return nil, &RouteFilterError{Position: start, Message: "expected community with " + strconv.Itoa(field.size) + " parts"}
+	}
+
+	match := func(r Route) bool {
+		for _, community := range field.commValue(r) {
+			if matchCommunity(community, pattern) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if op == "!=" {
+		return func(r Route) bool { return !match(r) }, nil
+	}
+
+	return match, nil
+}
+
+func (p *filterParser) parseNumber() (int, error) {
+	tok := p.next()
+
+	text := tok.text
+	if tok.kind == filterTokenWord && (strings.HasPrefix(text, "AS") || strings.HasPrefix(text, "as")) {
+		text = text[2:]
+	}
+
+	v, err := strconv.Atoi(text)
+	if tok.kind != filterTokenWord || err != nil {
+		return 0, &RouteFilterError{Position: tok.pos, Message: "expected number"}
+	}
+
+	return v, nil
+}
+
+func (p *filterParser) parseString() (string, error) {
+	tok := p.next()
+	if tok.kind != filterTokenWord && tok.kind != filterTokenString {
+		return "", &RouteFilterError{Position: tok.pos, Message: "expected value"}
+	}
+
+	return tok.text, nil
+}
+
+func stringMatcher(value string) func(s string) bool {
+	if prefix, err := netip.ParsePrefix(value); err == nil {
+		prefix = prefix.Masked()
+
+		return func(s string) bool {
+			if network, err := netip.ParsePrefix(s); err == nil {
+				return network.Bits() >= prefix.Bits() && prefix.Contains(network.Addr())
+			}
+			if addr, err := netip.ParseAddr(s); err == nil {
+				return prefix.Contains(addr.WithZone(""))
+			}
+			return false
+		}
+	}
+
+	return func(s string) bool {
+		return strings.Contains(s, value)
+	}
+}
+
+func matchPathMask(path, mask []int) bool {
+	reach := make([]bool, len(mask)+1)
+	reach[0] = true
+	skipPathWildcards(reach, mask)
+
+	for _, asn := range path {
+		next := make([]bool, len(mask)+1)
+
+		for j, m := range mask {
+			if !reach[j] {
+				continue
+			}
+
+			switch {
+			case m == -1:
+				next[j] = true
+			case m == -2 || m == asn:
+				next[j+1] = true
+			}
+		}
+
+		skipPathWildcards(next, mask)
+		reach = next
+	}
+
+	return reach[len(mask)]
+}
+
+func skipPathWildcards(reach []bool, mask []int) {
+	for j, m := range mask {
+		if reach[j] && m == -1 {
+			reach[j+1] = true
+		}
+	}
+}
+
+func compactPathMask(mask []int) []int {
+	var result []int
+	for _, m := range mask {
+		if m == -1 && len(result) > 0 && result[len(result)-1] == -1 {
+			continue
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+func matchCommunity(community, pattern []int) bool {
+	if len(community) != len(pattern) {
+		return false
+	}
+
+	for i, v := range pattern {
+		if v != -1 && community[i] != v {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,34 @@
+package birdparse
+
+import "fmt"
+
+type RouteFilter struct {
+	Expression string `json:"expression"`
+
+	match func(route Route) bool
+}
+
+func (f *RouteFilter) Match(route Route) bool {
+	return f.match(route)
+}
+
+func (f *RouteFilter) Filter(routes []Route) []Route {
+	result := []Route{}
+
+	for _, route := range routes {
+		if f.match(route) {
+			result = append(result, route)
+		}
+	}
+
+	return result
+}
+
+type RouteFilterError struct {
+	Position int    `json:"position"`
+	Message  string `json:"message"`
+}
+
+func (e *RouteFilterError) Error() string {
+	return fmt.Sprintf("route filter: position %d: %s", e.Position, e.Message)
+}
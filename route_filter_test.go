@@ -0,0 +1,142 @@
+package birdparse
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRouteFilter(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+Table master4:
+1.1.1.0/24           unicast [peer_a 2026-01-16] * (100) [AS13335i]
+        via 192.0.2.1 on eth0
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 65000 174 13335
+        BGP.next_hop: 192.0.2.1
+        BGP.local_pref: 200
+        BGP.community: (65000,100) (174,21000)
+                     unicast [peer_b 2026-01-16] (100) [AS13335i]
+        via 192.0.2.2 on eth0
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 3356 13335
+        BGP.next_hop: 192.0.2.2
+        BGP.local_pref: 100
+        BGP.med: 50
+        BGP.large_community: (65000, 1, 2)
+10.0.0.0/8           unicast [static4 2026-01-16] * (200)
+        via 10.151.104.1 on eth0
+10.1.0.0/16          unicast [ospf1 2026-01-16] * E2 (150/20/10000) [10.255.0.2]
+        via 10.151.104.2 on eth0`
+
+	routes := ParseRoutes(data)
+
+	tests := []struct {
+		expr     string
+		expected []string
+	}{
+		{`as_path ~ [* 13335 *] && community ~ (65000,*) && local_pref > 100`, []string{"peer_a"}},
+		{`as_path ~ [= * 13335 =]`, []string{"peer_a", "peer_b"}},
+		{`as_path ~ [= 3356 ? =]`, []string{"peer_b"}},
+		{`as_path ~ 174`, []string{"peer_a"}},
+		{`as_path = [3356 13335]`, []string{"peer_b"}},
+		{`as_path = [= 3356 13335 =]`, []string{"peer_b"}},
+		{`as_path = 13335`, nil},
+		{`as_path !~ [* 174 *]`, []string{"peer_b", "static4", "ospf1"}},
+		{`origin_as = AS13335 && as_path_len <= 2`, []string{"peer_b"}},
+		{`large_community ~ (65000,*,2) || med >= 50`, []string{"peer_b"}},
+		{`primary`, []string{"peer_a", "static4", "ospf1"}},
+		{`!primary`, []string{"peer_b"}},
+		{`primary = false`, []string{"peer_b"}},
+		{`network ~ 10.0.0.0/8`, []string{"static4", "ospf1"}},
+		{`network = 10.0.0.0/8`, []string{"static4"}},
+		{`gateway ~ 192.0.2.2/31 || proto = "ospf1"`, []string{"peer_b", "ospf1"}},
+		{`next_hop = 192.0.2.1`, []string{"peer_a"}},
+		{`type = BGP && (local_pref = 100 || community ~ (174,21000))`, []string{"peer_a", "peer_b"}},
+		{`from_protocol ~ peer && metric == 100`, []string{"peer_a", "peer_b"}},
+		{`local_pref < 1000`, []string{"peer_a", "peer_b"}},
+	}
+
+	for _, tt := range tests {
+		result, err := FilterRoutes(routes, tt.expr)
+		if err != nil {
+			t.Errorf("FilterRoutes(%q) error = %v", tt.expr, err)
+			continue
+		}
+
+		var protocols []string
+		for _, route := range result {
+			protocols = append(protocols, route.FromProtocol)
+		}
+
+		if !reflect.DeepEqual(protocols, tt.expected) {
+			t.Errorf("FilterRoutes(%q) = %v, want %v", tt.expr, protocols, tt.expected)
+		}
+	}
+}
+
+func TestRouteFilterErrors(t *testing.T) {
+	tests := []struct {
+		expr     string
+		position int
+	}{
+		{`foo = 1`, 0},
+		{`local_pref >`, 12},
+		{`local_pref ~ 100`, 11},
+		{`community ~ (1,2,3)`, 12},
+		{`as_path ~ [* x *]`, 13},
+		{`as_path ~ [* 13335 =]`, 19},
+		{`as_path ~ [= 13335 ]`, 19},
+		{`as_path = [* 13335 *]`, 11},
+		{`(primary`, 8},
+		{`primary &&`, 10},
+		{`network = "10.0.0.0/8`, 10},
+		{`metric = 1 $`, 11},
+		{`primary primary`, 8},
+	}
+
+	for _, tt := range tests {
+		_, err := CompileRouteFilter(tt.expr)
+
+		var filterErr *RouteFilterError
+		if !errors.As(err, &filterErr) {
+			t.Errorf("CompileRouteFilter(%q) error = %v, want RouteFilterError", tt.expr, err)
+			continue
+		}
+
+		if filterErr.Position != tt.position {
+			t.Errorf("CompileRouteFilter(%q) position = %d, want %d (%v)", tt.expr, filterErr.Position, tt.position, err)
+		}
+	}
+}
+
+func TestRouteFilterPathMaskWildcards(t *testing.T) {
+	path := make([]int, 40)
+	for i := range path {
+		path[i] = 64500 + i
+	}
+	route := Route{BGP: &RouteBGPInfo{ASPath: path}}
+
+	tests := []struct {
+		expr     string
+		expected bool
+	}{
+		{`as_path ~ [* * * * * * * * * * * * * * * * 1]`, false},
+		{`as_path ~ [* * * * * * * * * * * * * * * * 64539]`, true},
+		{`as_path ~ [= 64500 * * * ? * * 64539 =]`, true},
+		{`as_path ~ [= ? ? ? =]`, false},
+	}
+
+	for _, tt := range tests {
+		filter, err := CompileRouteFilter(tt.expr)
+		if err != nil {
+			t.Fatalf("CompileRouteFilter(%q) error = %v", tt.expr, err)
+		}
+
+		if result := filter.Match(route); result != tt.expected {
+			t.Errorf("Match(%q) = %v, want %v", tt.expr, result, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package birdparse
+
+import "net/netip"
+
+type routeIndexNode struct {
+	prefix   netip.Prefix
+	routes   []Route
+	children [2]*routeIndexNode
+}
+
+type RouteIndex struct {
+	v4   *routeIndexNode
+	v6   *routeIndexNode
+	size int
+}
+
+func NewRouteIndex(routes []Route) *RouteIndex {
+	idx := &RouteIndex{}
+
+	for _, route := range routes {
+		idx.Insert(route)
+	}
+
+	return idx
+}
+
+func (idx *RouteIndex) Insert(route Route) bool {
+	if !route.NetworkPrefix.IsValid() {
+		return false
+	}
+
+	prefix := route.NetworkPrefix.Masked()
+	root := &idx.v6
+	if prefix.Addr().Is4() {
+		root = &idx.v4
+	}
+
+	if insertRouteIndexNode(root, prefix, route) {
+		idx.size++
+	}
+
+	return true
+}
+
+func (idx *RouteIndex) Len() int {
+	return idx.size
+}
+
+func (idx *RouteIndex) Lookup(addr netip.Addr) (netip.Prefix, []Route, bool) {
+	addr = addr.Unmap().WithZone("")
+
+	var best *routeIndexNode
+	for node := idx.root(addr); node != nil && node.prefix.Contains(addr); {
+		if len(node.routes) > 0 {
+			best = node
+		}
+		if node.prefix.Bits() == addr.BitLen() {
+			break
+		}
+		node = node.children[addrBit(addr, node.prefix.Bits())]
+	}
+
+	if best == nil {
+		return netip.Prefix{}, nil, false
+	}
+
+	return best.prefix, best.routes, true
+}
+
+func (idx *RouteIndex) Exact(prefix netip.Prefix) []Route {
+	prefix = prefix.Masked()
+
+	for node := idx.root(prefix.Addr()); node != nil; {
+		if node.prefix.Bits() > prefix.Bits() || !node.prefix.Contains(prefix.Addr()) {
+			break
+		}
+		if node.prefix == prefix {
+			return node.routes
+		}
+		node = node.children[addrBit(prefix.Addr(), node.prefix.Bits())]
+	}
+
+	return nil
+}
+
+func (idx *RouteIndex) Covering(prefix netip.Prefix) []Route {
+	prefix = prefix.Masked()
+	var routes []Route
+
+	for node := idx.root(prefix.Addr()); node != nil; {
+		if node.prefix.Bits() > prefix.Bits() || !node.prefix.Contains(prefix.Addr()) {
+			break
+		}
+		routes = append(routes, node.routes...)
+		if node.prefix.Bits() == prefix.Bits() {
+			break
+		}
+		node = node.children[addrBit(prefix.Addr(), node.prefix.Bits())]
+	}
+
+	return routes
+}
+
+func (idx *RouteIndex) Covered(prefix netip.Prefix) []Route {
+	prefix = prefix.Masked()
+	var routes []Route
+
+	for node := idx.root(prefix.Addr()); node != nil; {
+		if node.prefix.Bits() >= prefix.Bits() {
+			if prefix.Contains(node.prefix.Addr()) {
+				walkRouteIndexNode(node, func(_ netip.Prefix, r []Route) bool {
+					routes = append(routes, r...)
+					return true
+				})
+			}
+			break
+		}
+		if !node.prefix.Contains(prefix.Addr()) {
+			break
+		}
+		node = node.children[addrBit(prefix.Addr(), node.prefix.Bits())]
+	}
+
+	return routes
+}
+
+func (idx *RouteIndex) Walk(fn func(prefix netip.Prefix, routes []Route) bool) {
+	if !walkRouteIndexNode(idx.v4, fn) {
+		return
+	}
+	walkRouteIndexNode(idx.v6, fn)
+}
+
+func (idx *RouteIndex) root(addr netip.Addr) *routeIndexNode {
+	if addr.Is4() {
+		return idx.v4
+	}
+	return idx.v6
+}
+
+func insertRouteIndexNode(n **routeIndexNode, prefix netip.Prefix, route Route) bool {
+	for {
+		node := *n
+		if node == nil {
+			*n = &routeIndexNode{prefix: prefix, routes: []Route{route}}
+			return true
+		}
+
+		common := commonPrefixBits(node.prefix, prefix)
+
+		switch {
+		case common == node.prefix.Bits() && common == prefix.Bits():
+			added := len(node.routes) == 0
+			node.routes = append(node.routes, route)
+			return added
+		case common == node.prefix.Bits():
+			n = &node.children[addrBit(prefix.Addr(), common)]
+		case common == prefix.Bits():
+			parent := &routeIndexNode{prefix: prefix, routes: []Route{route}}
+			parent.children[addrBit(node.prefix.Addr(), common)] = node
+			*n = parent
+			return true
+		default:
+			glue := &routeIndexNode{prefix: netip.PrefixFrom(prefix.Addr(), common).Masked()}
+			glue.children[addrBit(node.prefix.Addr(), common)] = node
+			glue.children[addrBit(prefix.Addr(), common)] = &routeIndexNode{prefix: prefix, routes: []Route{route}}
+			*n = glue
+			return true
+		}
+	}
+}
+
+func walkRouteIndexNode(node *routeIndexNode, fn func(prefix netip.Prefix, routes []Route) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	if len(node.routes) > 0 && !fn(node.prefix, node.routes) {
+		return false
+	}
+
+	return walkRouteIndexNode(node.children[0], fn) && walkRouteIndexNode(node.children[1], fn)
+}
+
+func commonPrefixBits(a, b netip.Prefix) int {
+	limit := min(a.Bits(), b.Bits())
+	ab, bb := a.Addr().AsSlice(), b.Addr().AsSlice()
+
+	for i := 0; i < limit; i++ {
+		if bitAt(ab, i) != bitAt(bb, i) {
+			return i
+		}
+	}
+
+	return limit
+}
+
+func addrBit(addr netip.Addr, i int) int {
+	return bitAt(addr.AsSlice(), i)
+}
+
+func bitAt(b []byte, i int) int {
+	return int(b[i/8]>>(7-uint(i%8))) & 1
+}
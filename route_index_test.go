@@ -0,0 +1,107 @@
+package birdparse
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func TestRouteIndex(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+Table master4:
+0.0.0.0/0            unicast [kernel4 2026-01-16] * (10)
+        via 10.151.104.1 on eth0
+10.0.0.0/8           unicast [static4 2026-01-16] * (200)
+        via 10.151.104.1 on eth0
+10.1.0.0/16          unicast [ospf1 2026-01-16] * (150/20) [10.255.0.2]
+        via 10.151.104.2 on eth0
+                     unicast [ospf2 2026-01-16] (150/30) [10.255.0.3]
+        via 10.151.104.3 on eth0
+10.1.2.0/24          unicast [ospf1 2026-01-16] * (150/20) [10.255.0.2]
+        via 10.151.104.2 on eth0
+10.2.0.0/16          unicast [ospf1 2026-01-16] * (150/20) [10.255.0.2]
+        via 10.151.104.2 on eth0
+192.0.2.0/24         unicast [static4 2026-01-16] * (200)
+        via 10.151.104.1 on eth0
+Table master6:
+2001:db8::/32        unicast [static6 2026-01-16] * (200)
+        via fe80::1 on eth0
+2001:db8:1::/48      unicast [bgp6 2026-01-16] * (100) [AS64500i]
+        via fe80::2 on eth0`
+
+	idx := NewRouteIndex(ParseRoutes(data))
+
+	if idx.Len() != 8 {
+		t.Errorf("Len() = %d, want 8", idx.Len())
+	}
+
+	networks := func(routes []Route) []string {
+		var result []string
+		for _, route := range routes {
+			result = append(result, route.Network+"@"+route.FromProtocol)
+		}
+		return result
+	}
+
+	prefix, routes, ok := idx.Lookup(netip.MustParseAddr("10.1.2.3"))
+	if !ok || prefix != netip.MustParsePrefix("10.1.2.0/24") || len(routes) != 1 {
+		t.Errorf("Lookup(10.1.2.3) = %v, %v, %v", prefix, routes, ok)
+	}
+
+	prefix, _, ok = idx.Lookup(netip.MustParseAddr("10.1.3.1"))
+	if !ok || prefix != netip.MustParsePrefix("10.1.0.0/16") {
+		t.Errorf("Lookup(10.1.3.1) = %v, %v", prefix, ok)
+	}
+
+	prefix, _, ok = idx.Lookup(netip.MustParseAddr("8.8.8.8"))
+	if !ok || prefix != netip.MustParsePrefix("0.0.0.0/0") {
+		t.Errorf("Lookup(8.8.8.8) = %v, %v", prefix, ok)
+	}
+
+	prefix, _, ok = idx.Lookup(netip.MustParseAddr("2001:db8:1::1"))
+	if !ok || prefix != netip.MustParsePrefix("2001:db8:1::/48") {
+		t.Errorf("Lookup(2001:db8:1::1) = %v, %v", prefix, ok)
+	}
+
+	if _, _, ok = idx.Lookup(netip.MustParseAddr("2001:db9::1")); ok {
+		t.Errorf("Lookup(2001:db9::1) found a route")
+	}
+
+	if got := networks(idx.Exact(netip.MustParsePrefix("10.1.0.0/16"))); !reflect.DeepEqual(got, []string{"10.1.0.0/16@ospf1", "10.1.0.0/16@ospf2"}) {
+		t.Errorf("Exact(10.1.0.0/16) = %v", got)
+	}
+
+	if got := idx.Exact(netip.MustParsePrefix("10.1.0.0/17")); got != nil {
+		t.Errorf("Exact(10.1.0.0/17) = %v, want nil", got)
+	}
+
+	if got := networks(idx.Covering(netip.MustParsePrefix("10.1.2.0/24"))); !reflect.DeepEqual(got, []string{
+		"0.0.0.0/0@kernel4", "10.0.0.0/8@static4", "10.1.0.0/16@ospf1", "10.1.0.0/16@ospf2", "10.1.2.0/24@ospf1",
+	}) {
+		t.Errorf("Covering(10.1.2.0/24) = %v", got)
+	}
+
+	if got := networks(idx.Covered(netip.MustParsePrefix("10.0.0.0/8"))); !reflect.DeepEqual(got, []string{
+		"10.0.0.0/8@static4", "10.1.0.0/16@ospf1", "10.1.0.0/16@ospf2", "10.1.2.0/24@ospf1", "10.2.0.0/16@ospf1",
+	}) {
+		t.Errorf("Covered(10.0.0.0/8) = %v", got)
+	}
+
+	if got := networks(idx.Covered(netip.MustParsePrefix("10.0.0.0/15"))); !reflect.DeepEqual(got, []string{
+		"10.1.0.0/16@ospf1", "10.1.0.0/16@ospf2", "10.1.2.0/24@ospf1",
+	}) {
+		t.Errorf("Covered(10.0.0.0/15) = %v", got)
+	}
+
+	var walked []string
+	idx.Walk(func(prefix netip.Prefix, routes []Route) bool {
+		walked = append(walked, prefix.String())
+		return true
+	})
+
+	if !reflect.DeepEqual(walked, []string{
+		"0.0.0.0/0", "10.0.0.0/8", "10.1.0.0/16", "10.1.2.0/24", "10.2.0.0/16", "192.0.2.0/24", "2001:db8::/32", "2001:db8:1::/48",
+	}) {
+		t.Errorf("Walk() = %v", walked)
+	}
+}
@@ -1,17 +1,45 @@
 package birdparse
 
+import "net/netip"
+
 type Route struct {
-	Network      string         `json:"network"`
-	Gateway      string         `json:"gateway"`
-	Interface    string         `json:"interface"`
-	FromProtocol string         `json:"from_protocol"`
-	FromAddress  string         `json:"from_address"`
-	Primary      bool           `json:"primary"`
-	Metric       int            `json:"metric"`
-	IGPMetric    int            `json:"igp_metric"`
-	Type         []string       `json:"type"`
-	BGP          *RouteBGPInfo  `json:"bgp"`
-	OSPF         *RouteOSPFInfo `json:"ospf"`
+	Network      string           `json:"network"`
+	Gateway      string           `json:"gateway"`
+	Interface    string           `json:"interface"`
+	FromProtocol string           `json:"from_protocol"`
+	Since        string           `json:"since"`
+	FromAddress  string           `json:"from_address"`
+	Primary      bool             `json:"primary"`
+	Metric       int              `json:"metric"`
+	IGPMetric    int              `json:"igp_metric"`
+	MPLSLabels   []int            `json:"mpls_labels"`
+	Type         []string         `json:"type"`
+	BGP          *RouteBGPInfo    `json:"bgp"`
+	OSPF         *RouteOSPFInfo   `json:"ospf"`
+	Babel        *RouteBabelInfo  `json:"babel"`
+	RIP          *RouteRIPInfo    `json:"rip"`
+	Kernel       *RouteKernelInfo `json:"kernel"`
+	Attributes   []RouteAttribute `json:"attributes"`
+
+	NetworkPrefix netip.Prefix `json:"-"`
+	GatewayAddr   netip.Addr   `json:"-"`
+	FromAddr      netip.Addr   `json:"-"`
+	Errors        []error      `json:"-"`
+}
+
+type RouteAttribute struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (r Route) Attribute(name string) (string, bool) {
+	for _, attribute := range r.Attributes {
+		if attribute.Name == name {
+			return attribute.Value, true
+		}
+	}
+
+	return "", false
 }
 
 type RouteBGPInfo struct {
@@ -24,9 +52,42 @@ type RouteBGPInfo struct {
 	Aggregator       string   `json:"aggregator"`
 	Communities      [][]int  `json:"communities"`
 	LargeCommunities [][]int  `json:"large_communities"`
+	OTC              int      `json:"otc"`
 }
 
+type OSPFRouteType string
+
+const (
+	OSPFRouteTypeIntraArea OSPFRouteType = "OSPF"
+	OSPFRouteTypeInterArea OSPFRouteType = "OSPF-IA"
+	OSPFRouteTypeExternal1 OSPFRouteType = "OSPF-E1"
+	OSPFRouteTypeExternal2 OSPFRouteType = "OSPF-E2"
+)
+
 type RouteOSPFInfo struct {
-	Metric1  int    `json:"metric_1"`
+	RouteType OSPFRouteType `json:"route_type"`
+	Metric1   int           `json:"metric_1"`
+	Metric2   int           `json:"metric_2"`
+	Tag       int           `json:"tag"`
+	RouterID  string        `json:"router_id"`
+}
+
+func (o RouteOSPFInfo) IsExternal() bool {
+	return o.RouteType == OSPFRouteTypeExternal1 || o.RouteType == OSPFRouteTypeExternal2
+}
+
+type RouteBabelInfo struct {
+	Metric   int    `json:"metric"`
+	Seqno    int    `json:"seqno"`
 	RouterID string `json:"router_id"`
 }
+
+type RouteRIPInfo struct {
+	Metric int `json:"metric"`
+	Tag    int `json:"tag"`
+}
+
+type RouteKernelInfo struct {
+	Source int `json:"source"`
+	Metric int `json:"metric"`
+}
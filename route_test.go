@@ -1,6 +1,7 @@
 package birdparse
 
 import (
+	"net/netip"
 	"reflect"
 	"testing"
 )
@@ -34,14 +35,18 @@ Table master4:
 
 	expected := []Route{
 		{
-			Network:      "40.0.0.0/14",
-			Gateway:      "10.151.104.1",
-			Interface:    "eth0",
-			FromProtocol: "us_44324_4",
-			FromAddress:  "1.1.1.1",
-			Primary:      true,
-			Metric:       100,
-			Type:         []string{"BGP", "univ"},
+			Network:       "40.0.0.0/14",
+			NetworkPrefix: netip.MustParsePrefix("40.0.0.0/14"),
+			Gateway:       "10.151.104.1",
+			GatewayAddr:   netip.MustParseAddr("10.151.104.1"),
+			Interface:     "eth0",
+			FromProtocol:  "us_44324_4",
+			Since:         "2026-01-19",
+			FromAddress:   "1.1.1.1",
+			FromAddr:      netip.MustParseAddr("1.1.1.1"),
+			Primary:       true,
+			Metric:        100,
+			Type:          []string{"BGP", "univ"},
 			BGP: &RouteBGPInfo{
 				Origin:     "IGP",
 				ASPath:     []int{44324, 216211, 30058, 2914, 32787, 4249},
@@ -68,16 +73,30 @@ Table master4:
 					{215172, 6, 44324},
 				},
 			},
+			Attributes: []RouteAttribute{
+				{Name: "Type", Value: "BGP univ"},
+				{Name: "BGP.origin", Value: "IGP"},
+				{Name: "BGP.as_path", Value: "44324 216211 30058 2914 32787 4249"},
+				{Name: "BGP.next_hop", Value: "10.151.104.1"},
+				{Name: "BGP.local_pref", Value: "100"},
+				{Name: "BGP.atomic_aggr", Value: ""},
+				{Name: "BGP.aggregator", Value: "40.15.254.191 AS4249"},
+				{Name: "BGP.community", Value: "(2914,410) (2914,1408) (2914,2401) (2914,3400) (32787,64015) (32787,65522)"},
+				{Name: "BGP.large_community", Value: "(44324, 10000, 52) (44324, 10001, 392) (215172, 0, 100) (215172, 3, 1392) (215172, 3, 52) (215172, 3, 3003) (215172, 5, 2) (215172, 6, 44324)"},
+			},
 		},
 		{
-			Network:      "40.0.0.0/14",
-			Gateway:      "1.2.3.4",
-			Interface:    "eth1",
-			FromProtocol: "us_1234_4",
-			FromAddress:  "",
-			Primary:      false,
-			Metric:       100,
-			Type:         []string{"BGP", "univ"},
+			Network:       "40.0.0.0/14",
+			NetworkPrefix: netip.MustParsePrefix("40.0.0.0/14"),
+			Gateway:       "1.2.3.4",
+			GatewayAddr:   netip.MustParseAddr("1.2.3.4"),
+			Interface:     "eth1",
+			FromProtocol:  "us_1234_4",
+			Since:         "12:16:59.123",
+			FromAddress:   "",
+			Primary:       false,
+			Metric:        100,
+			Type:          []string{"BGP", "univ"},
 			BGP: &RouteBGPInfo{
 				Origin:     "IGP",
 				ASPath:     []int{1234, 4249},
@@ -104,6 +123,17 @@ Table master4:
 					{215172, 6, 44324},
 				},
 			},
+			Attributes: []RouteAttribute{
+				{Name: "Type", Value: "BGP univ"},
+				{Name: "BGP.origin", Value: "IGP"},
+				{Name: "BGP.as_path", Value: "1234 4249"},
+				{Name: "BGP.next_hop", Value: "1.2.3.4"},
+				{Name: "BGP.local_pref", Value: "100"},
+				{Name: "BGP.atomic_aggr", Value: ""},
+				{Name: "BGP.aggregator", Value: "40.15.254.191 AS4249"},
+				{Name: "BGP.community", Value: "(2914,410) (2914,1408) (2914,2401) (2914,3400) (32787,64015) (32787,65522)"},
+				{Name: "BGP.large_community", Value: "(44324, 10000, 52) (44324, 10001, 392) (215172, 0, 100) (215172, 3, 1392) (215172, 3, 52) (215172, 3, 3003) (215172, 5, 2) (215172, 6, 44324)"},
+			},
 		},
 	}
 
@@ -160,15 +190,19 @@ Table master6:
 
 	expected := []Route{
 		{
-			Network:      "2a0a:2c0:1a::/48",
-			Gateway:      "fe80::5efe:a64:bfe",
-			Interface:    "tyom10",
-			FromProtocol: "rr_tyom10",
-			FromAddress:  "2001:678:11a4::2",
-			Primary:      true,
-			Metric:       100,
-			IGPMetric:    145,
-			Type:         []string{"BGP", "univ"},
+			Network:       "2a0a:2c0:1a::/48",
+			NetworkPrefix: netip.MustParsePrefix("2a0a:2c0:1a::/48"),
+			Gateway:       "fe80::5efe:a64:bfe",
+			GatewayAddr:   netip.MustParseAddr("fe80::5efe:a64:bfe"),
+			Interface:     "tyom10",
+			FromProtocol:  "rr_tyom10",
+			Since:         "23:41:27.768",
+			FromAddress:   "2001:678:11a4::2",
+			FromAddr:      netip.MustParseAddr("2001:678:11a4::2"),
+			Primary:       true,
+			Metric:        100,
+			IGPMetric:     145,
+			Type:          []string{"BGP", "univ"},
 			BGP: &RouteBGPInfo{
 				Origin:    "IGP",
 				ASPath:    []int{50263, 48648, 210092},
@@ -201,17 +235,32 @@ Table master6:
 					{215172, 7, 3756},
 				},
 			},
+			Attributes: []RouteAttribute{
+				{Name: "Type", Value: "BGP univ"},
+				{Name: "BGP.origin", Value: "IGP"},
+				{Name: "BGP.as_path", Value: "50263 48648 210092"},
+				{Name: "BGP.next_hop", Value: "2001:678:11a4::12"},
+				{Name: "BGP.local_pref", Value: "205"},
+				{Name: "BGP.community", Value: "(0,3255) (0,3326) (0,6768) (0,8647) (0,12883) (0,12963) (0,13249) (0,13335) (0,14061) (0,15169) (0,15895) (0,20764) (0,20940) (0,21497) (0,22697) (0,25133) (0,25229) (0,25521) (0,29632) (0,30058) (0,34927) (0,35297) (0,35320) (0,39737) (0,41820) (0,43668) (0,44600) (0,44854) (0,47787) (0,48011) (0,48919) (0,49824) (0,50581) (0,54994) (0,61049) (0,62041) (0,64289) (48648,6197)"},
+				{Name: "BGP.originator_id", Value: "118.91.186.99"},
+				{Name: "BGP.cluster_list", Value: "0.0.0.1"},
+				{Name: "BGP.ext_community", Value: "(rt, 48648, 3)"},
+				{Name: "BGP.large_community", Value: "(50263, 1910, 437) (50263, 1911, 32) (50263, 1912, 101) (50263, 1913, 804) (50263, 1914, 150) (50263, 1915, 1) (215172, 0, 200) (215172, 3, 52) (215172, 3, 1085) (215172, 3, 3012) (215172, 5, 1) (215172, 5, 5) (215172, 6, 47498) (215172, 7, 3756)"},
+			},
 		},
 		{
-			Network:      "2a0a:2c0:1a::/48",
-			Gateway:      "fc00:230::1",
-			Interface:    "eth0",
-			FromProtocol: "us_44324_6",
-			FromAddress:  "",
-			Primary:      false,
-			Metric:       100,
-			IGPMetric:    0,
-			Type:         []string{"BGP", "univ"},
+			Network:       "2a0a:2c0:1a::/48",
+			NetworkPrefix: netip.MustParsePrefix("2a0a:2c0:1a::/48"),
+			Gateway:       "fc00:230::1",
+			GatewayAddr:   netip.MustParseAddr("fc00:230::1"),
+			Interface:     "eth0",
+			FromProtocol:  "us_44324_6",
+			Since:         "23:41:27.768",
+			FromAddress:   "",
+			Primary:       false,
+			Metric:        100,
+			IGPMetric:     0,
+			Type:          []string{"BGP", "univ"},
 			BGP: &RouteBGPInfo{
 				Origin:    "IGP",
 				ASPath:    []int{44324, 216211, 6939, 35297, 48648, 210092},
@@ -235,17 +284,29 @@ Table master6:
 					{215172, 6, 44324},
 				},
 			},
+			Attributes: []RouteAttribute{
+				{Name: "Type", Value: "BGP univ"},
+				{Name: "BGP.origin", Value: "IGP"},
+				{Name: "BGP.as_path", Value: "44324 216211 6939 35297 48648 210092"},
+				{Name: "BGP.next_hop", Value: "fc00:230::1 fe80::fcb2:c6ff:fe2a:691"},
+				{Name: "BGP.local_pref", Value: "100"},
+				{Name: "BGP.community", Value: "(23640,65012) (65101,30) (65102,392) (65103,3921) (65104,3921)"},
+				{Name: "BGP.large_community", Value: "(44324, 10000, 52) (44324, 10001, 392) (215172, 0, 100) (215172, 3, 1392) (215172, 3, 52) (215172, 3, 3003) (215172, 5, 1) (215172, 6, 44324)"},
+			},
 		},
 		{
-			Network:      "2001:44b8:4040::/48",
-			Gateway:      "fc00:230::1",
-			Interface:    "eth0",
-			FromProtocol: "us_44324_6",
-			FromAddress:  "",
-			Primary:      true,
-			Metric:       100,
-			IGPMetric:    0,
-			Type:         []string{"BGP", "univ"},
+			Network:       "2001:44b8:4040::/48",
+			NetworkPrefix: netip.MustParsePrefix("2001:44b8:4040::/48"),
+			Gateway:       "fc00:230::1",
+			GatewayAddr:   netip.MustParseAddr("fc00:230::1"),
+			Interface:     "eth0",
+			FromProtocol:  "us_44324_6",
+			Since:         "2026-01-15",
+			FromAddress:   "",
+			Primary:       true,
+			Metric:        100,
+			IGPMetric:     0,
+			Type:          []string{"BGP", "univ"},
 			BGP: &RouteBGPInfo{
 				Origin:    "IGP",
 				ASPath:    []int{44324, 216211, 3491, 3491, 6453, 7545},
@@ -267,6 +328,15 @@ Table master6:
 					{215172, 6, 44324},
 				},
 			},
+			Attributes: []RouteAttribute{
+				{Name: "Type", Value: "BGP univ"},
+				{Name: "BGP.origin", Value: "IGP"},
+				{Name: "BGP.as_path", Value: "44324 216211 3491 3491 6453 7545"},
+				{Name: "BGP.next_hop", Value: "fc00:230::1 fe80::fcb2:c6ff:fe2a:691"},
+				{Name: "BGP.local_pref", Value: "100"},
+				{Name: "BGP.community", Value: "(3491,4000) (3491,4019) (3491,9002)"},
+				{Name: "BGP.large_community", Value: "(44324, 10000, 52) (44324, 10001, 392) (215172, 0, 100) (215172, 3, 1392) (215172, 3, 52) (215172, 3, 3003) (215172, 5, 2) (215172, 6, 44324)"},
+			},
 		},
 	}
 
@@ -295,17 +365,97 @@ Table master6:
 
 	expected := []Route{
 		{
-			Network:      "2001:678:11a4::4/128",
-			Gateway:      "fe80::200:5efe:1797:6804",
-			Interface:    "tyoe20",
-			FromProtocol: "lpnet_ospf",
-			Primary:      true,
-			Metric:       150,
-			IGPMetric:    10,
-			Type:         []string{"OSPF", "univ"},
+			Network:       "2001:678:11a4::4/128",
+			NetworkPrefix: netip.MustParsePrefix("2001:678:11a4::4/128"),
+			Gateway:       "fe80::200:5efe:1797:6804",
+			GatewayAddr:   netip.MustParseAddr("fe80::200:5efe:1797:6804"),
+			Interface:     "tyoe20",
+			FromProtocol:  "lpnet_ospf",
+			Since:         "10:56:39.545",
+			Primary:       true,
+			Metric:        150,
+			IGPMetric:     10,
+			Type:          []string{"OSPF", "univ"},
+			OSPF: &RouteOSPFInfo{
+				RouteType: OSPFRouteTypeIntraArea,
+				Metric1:   10,
+				RouterID:  "82.39.145.1",
+			},
+			Attributes: []RouteAttribute{
+				{Name: "Type", Value: "OSPF univ"},
+				{Name: "OSPF.metric1", Value: "10"},
+				{Name: "OSPF.router_id", Value: "82.39.145.1"},
+			},
+		},
+	}
+
+	result := ParseRoutes(data)
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d routes, got %d", len(expected), len(result))
+	}
+
+	for i, route := range result {
+		if !reflect.DeepEqual(route, expected[i]) {
+			t.Errorf("Route %d mismatch:\nGot: %+v\nExpected: %+v", i, route, expected[i])
+		}
+	}
+}
+
+func TestParseOSPFExternalRoutes(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+Access restricted
+Table master4:
+0.0.0.0/0            unicast [lpnet_ospf 10:56:39.545] * E2 (150/10/10000) [2a] [82.39.145.9]
+        via 10.151.104.9 on eth0
+        Type: OSPF-E2 univ
+        OSPF.metric1: 10
+        OSPF.metric2: 10000
+        OSPF.tag: 0x0000002a
+        OSPF.router_id: 82.39.145.9
+192.0.2.0/24         unicast [lpnet_ospf 10:56:39.545] IA (150/30) [82.39.145.2]
+        via 10.151.104.2 on eth0`
+
+	expected := []Route{
+		{
+			Network:       "0.0.0.0/0",
+			NetworkPrefix: netip.MustParsePrefix("0.0.0.0/0"),
+			Gateway:       "10.151.104.9",
+			GatewayAddr:   netip.MustParseAddr("10.151.104.9"),
+			Interface:     "eth0",
+			FromProtocol:  "lpnet_ospf",
+			Since:         "10:56:39.545",
+			Primary:       true,
+			Metric:        150,
+			IGPMetric:     10,
+			Type:          []string{"OSPF-E2", "univ"},
+			OSPF: &RouteOSPFInfo{
+				RouteType: OSPFRouteTypeExternal2,
+				Metric1:   10,
+				Metric2:   10000,
+				Tag:       42,
+				RouterID:  "82.39.145.9",
+			},
+			Attributes: []RouteAttribute{
+				{Name: "Type", Value: "OSPF-E2 univ"},
+				{Name: "OSPF.metric1", Value: "10"},
+				{Name: "OSPF.metric2", Value: "10000"},
+				{Name: "OSPF.tag", Value: "0x0000002a"},
+				{Name: "OSPF.router_id", Value: "82.39.145.9"},
+			},
+		},
+		{
+			Network:       "192.0.2.0/24",
+			NetworkPrefix: netip.MustParsePrefix("192.0.2.0/24"),
+			Gateway:       "10.151.104.2",
+			GatewayAddr:   netip.MustParseAddr("10.151.104.2"),
+			Interface:     "eth0",
+			FromProtocol:  "lpnet_ospf",
+			Since:         "10:56:39.545",
+			Metric:        150,
+			IGPMetric:     30,
 			OSPF: &RouteOSPFInfo{
-				Metric1:  10,
-				RouterID: "82.39.145.1",
+				RouteType: OSPFRouteTypeInterArea,
 			},
 		},
 	}
@@ -321,4 +471,228 @@ Table master6:
 			t.Errorf("Route %d mismatch:\nGot: %+v\nExpected: %+v", i, route, expected[i])
 		}
 	}
+
+	if !result[0].OSPF.IsExternal() || result[1].OSPF.IsExternal() {
+		t.Errorf("IsExternal() mismatch")
+	}
+}
+
+func TestParseBabelRoute(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+Table master4:
+10.66.2.0/24         unicast [babel1 10:56:39.545] * (130/96) [0:0:0:2]
+        via 10.66.0.2 on wg0
+        Type: Babel univ
+        Babel.metric: 96
+        Babel.router_id: 0:0:0:2`
+
+	expected := []Route{
+		{
+			Network:       "10.66.2.0/24",
+			NetworkPrefix: netip.MustParsePrefix("10.66.2.0/24"),
+			Gateway:       "10.66.0.2",
+			GatewayAddr:   netip.MustParseAddr("10.66.0.2"),
+			Interface:     "wg0",
+			FromProtocol:  "babel1",
+			Since:         "10:56:39.545",
+			Primary:       true,
+			Metric:        130,
+			IGPMetric:     96,
+			Type:          []string{"Babel", "univ"},
+			Babel: &RouteBabelInfo{
+				Metric:   96,
+				RouterID: "0:0:0:2",
+			},
+			Attributes: []RouteAttribute{
+				{Name: "Type", Value: "Babel univ"},
+				{Name: "Babel.metric", Value: "96"},
+				{Name: "Babel.router_id", Value: "0:0:0:2"},
+			},
+		},
+	}
+
+	result := ParseRoutes(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseRoutes() = %+v, want %+v", result, expected)
+	}
+}
+
+func TestParseRIPRoute(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+Table master4:
+10.20.0.0/16         unicast [rip1 10:56:39.545] * (120/3) [002a]
+        via 10.151.104.2 on eth0
+        Type: RIP univ
+        RIP.metric: 3
+        RIP.tag: 002a`
+
+	expected := []Route{
+		{
+			Network:       "10.20.0.0/16",
+			NetworkPrefix: netip.MustParsePrefix("10.20.0.0/16"),
+			Gateway:       "10.151.104.2",
+			GatewayAddr:   netip.MustParseAddr("10.151.104.2"),
+			Interface:     "eth0",
+			FromProtocol:  "rip1",
+			Since:         "10:56:39.545",
+			Primary:       true,
+			Metric:        120,
+			IGPMetric:     3,
+			Type:          []string{"RIP", "univ"},
+			RIP: &RouteRIPInfo{
+				Metric: 3,
+				Tag:    42,
+			},
+			Attributes: []RouteAttribute{
+				{Name: "Type", Value: "RIP univ"},
+				{Name: "RIP.metric", Value: "3"},
+				{Name: "RIP.tag", Value: "002a"},
+			},
+		},
+	}
+
+	result := ParseRoutes(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseRoutes() = %+v, want %+v", result, expected)
+	}
+}
+
+func TestParseKernelRoute(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+Table master4:
+0.0.0.0/0            unicast [kernel4 2026-01-16] * (10)
+        via 10.151.104.1 on eth0
+        Type: inherit univ
+        Kernel.source: 3
+        Kernel.metric: 100`
+
+	expected := []Route{
+		{
+			Network:       "0.0.0.0/0",
+			NetworkPrefix: netip.MustParsePrefix("0.0.0.0/0"),
+			Gateway:       "10.151.104.1",
+			GatewayAddr:   netip.MustParseAddr("10.151.104.1"),
+			Interface:     "eth0",
+			FromProtocol:  "kernel4",
+			Since:         "2026-01-16",
+			Primary:       true,
+			Metric:        10,
+			Type:          []string{"inherit", "univ"},
+			Kernel: &RouteKernelInfo{
+				Source: 3,
+				Metric: 100,
+			},
+			Attributes: []RouteAttribute{
+				{Name: "Type", Value: "inherit univ"},
+				{Name: "Kernel.source", Value: "3"},
+				{Name: "Kernel.metric", Value: "100"},
+			},
+		},
+	}
+
+	result := ParseRoutes(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseRoutes() = %+v, want %+v", result, expected)
+	}
+}
+
+func TestParseRouteUnknownAttributes(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+Table master4:
+192.0.2.0/24         unicast [us_44324_4 2026-01-19] * (100) [AS64500i]
+        via 10.151.104.1 on eth0
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 44324 64500
+        BGP.next_hop: 10.151.104.1
+        BGP.otc: 44324
+        BGP.community: (44324,100)
+        BGP.unknown.0xe0: [t] 00 01 02 03
+        bgp_custom_tag: 17
+        BGP.local_pref: 100`
+
+	expected := []Route{
+		{
+			Network:       "192.0.2.0/24",
+			NetworkPrefix: netip.MustParsePrefix("192.0.2.0/24"),
+			Gateway:       "10.151.104.1",
+			GatewayAddr:   netip.MustParseAddr("10.151.104.1"),
+			Interface:     "eth0",
+			FromProtocol:  "us_44324_4",
+			Since:         "2026-01-19",
+			Primary:       true,
+			Metric:        100,
+			Type:          []string{"BGP", "univ"},
+			BGP: &RouteBGPInfo{
+				Origin:      "IGP",
+				ASPath:      []int{44324, 64500},
+				NextHop:     []string{"10.151.104.1"},
+				LocalPref:   100,
+				Communities: [][]int{{44324, 100}},
+				OTC:         44324,
+			},
+			Attributes: []RouteAttribute{
+				{Name: "Type", Value: "BGP univ"},
+				{Name: "BGP.origin", Value: "IGP"},
+				{Name: "BGP.as_path", Value: "44324 64500"},
+				{Name: "BGP.next_hop", Value: "10.151.104.1"},
+				{Name: "BGP.otc", Value: "44324"},
+				{Name: "BGP.community", Value: "(44324,100)"},
+				{Name: "BGP.unknown.0xe0", Value: "[t] 00 01 02 03"},
+				{Name: "bgp_custom_tag", Value: "17"},
+				{Name: "BGP.local_pref", Value: "100"},
+			},
+		},
+	}
+
+	result := ParseRoutes(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("ParseRoutes() = %+v, want %+v", result, expected)
+	}
+
+	if value, ok := result[0].Attribute("bgp_custom_tag"); !ok || value != "17" {
+		t.Errorf("Attribute() = %q, %v, want %q, true", value, ok, "17")
+	}
+
+	if _, ok := result[0].Attribute("BGP.med"); ok {
+		t.Errorf("Attribute() found missing attribute")
+	}
+}
+
+func TestParseRouteAddrs(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+Table master4:
+192.0.2.0/24         unicast [us_44324_4 2026-01-19 from 10.151.104.1] * (100) [AS64500i]
+        via 10.151.104.1 on eth0
+10.0.0.0/33          unreachable [static4 2026-01-19] * (200)`
+
+	result := ParseRoutes(data)
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 routes, got %d", len(result))
+	}
+
+	if !result[0].NetworkPrefix.Contains(netip.MustParseAddr("192.0.2.10")) {
+		t.Errorf("NetworkPrefix = %v, want 192.0.2.0/24", result[0].NetworkPrefix)
+	}
+
+	if !result[0].GatewayAddr.Is4() || result[0].FromAddr != netip.MustParseAddr("10.151.104.1") {
+		t.Errorf("GatewayAddr = %v, FromAddr = %v", result[0].GatewayAddr, result[0].FromAddr)
+	}
+
+	if len(result[0].Errors) != 0 {
+		t.Errorf("Errors = %v, want none", result[0].Errors)
+	}
+
+	if result[1].GatewayAddr.IsValid() || result[1].NetworkPrefix.IsValid() {
+		t.Errorf("Route 1 typed fields = %v, %v, want zero values", result[1].GatewayAddr, result[1].NetworkPrefix)
+	}
+
+	if len(result[1].Errors) != 1 {
+		t.Errorf("Errors = %v, want one network error", result[1].Errors)
+	}
 }
@@ -0,0 +1,84 @@
+package birdparse
+
+import (
+	"regexp"
+	"strings"
+)
+
+func ParseStaticRoutes(data string) []StaticRoute {
+	routes := []StaticRoute{}
+	var protocol string
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		if strings.HasPrefix(line, "BIRD") ||
+			strings.HasPrefix(line, "Access restricted") {
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(\S+):$`).FindStringSubmatch(line); m != nil {
+			protocol = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(\S+/\d+)\s+recursive\s+(\S+)$`).FindStringSubmatch(line); m != nil {
+			routes = append(routes, StaticRoute{
+				Protocol:    protocol,
+				Network:     m[1],
+				Destination: "recursive",
+				NextHops:    []StaticRouteNextHop{{Gateway: m[2]}},
+			})
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(\S+/\d+)\s+(\w+)$`).FindStringSubmatch(line); m != nil {
+			routes = append(routes, StaticRoute{
+				Protocol:    protocol,
+				Network:     m[1],
+				Destination: m[2],
+			})
+			continue
+		}
+
+		if m := regexp.MustCompile(`^(\S+/\d+)$`).FindStringSubmatch(line); m != nil {
+			routes = append(routes, StaticRoute{
+				Protocol:    protocol,
+				Network:     m[1],
+				Destination: "unicast",
+			})
+			continue
+		}
+
+		if len(routes) == 0 || routes[len(routes)-1].Destination != "unicast" {
+			continue
+		}
+		route := &routes[len(routes)-1]
+
+		if m := regexp.MustCompile(`^\s+dev\s+(\S+)(\s+\(dormant\))?$`).FindStringSubmatch(line); m != nil {
+			route.NextHops = append(route.NextHops, StaticRouteNextHop{
+				Interface: m[1],
+				Dormant:   m[2] != "",
+			})
+			continue
+		}
+
+		if m := regexp.MustCompile(`^\s+via\s+([0-9a-fA-F.:]+)(?:%(\S+?))?(?:\s+on\s+(\S+))?(\s+onlink)?(\s+\(bfd\))?(\s+\(dormant\))?$`).FindStringSubmatch(line); m != nil {
+			nextHop := StaticRouteNextHop{
+				Gateway:   m[1],
+				Interface: m[2],
+				Onlink:    m[4] != "",
+				BFD:       m[5] != "",
+				Dormant:   m[6] != "",
+			}
+			if m[3] != "" {
+				nextHop.Interface = m[3]
+			}
+			route.NextHops = append(route.NextHops, nextHop)
+		}
+	}
+
+	return routes
+}
@@ -0,0 +1,16 @@
+package birdparse
+
+type StaticRouteNextHop struct {
+	Gateway   string `json:"gateway"`
+	Interface string `json:"interface"`
+	Onlink    bool   `json:"onlink"`
+	BFD       bool   `json:"bfd"`
+	Dormant   bool   `json:"dormant"`
+}
+
+type StaticRoute struct {
+	Protocol    string               `json:"protocol"`
+	Network     string               `json:"network"`
+	Destination string               `json:"destination"`
+	NextHops    []StaticRouteNextHop `json:"next_hops"`
+}
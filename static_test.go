@@ -0,0 +1,68 @@
+package birdparse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseStaticRoutes(t *testing.T) {
+	data := "BIRD 2.17.1 ready.\n" +
+		"static4:\n" +
+		"10.0.0.0/8\tblackhole\n" +
+		"192.0.2.0/24\n" +
+		"\tvia 10.151.104.1 (bfd)\n" +
+		"\tvia 10.151.104.2 onlink (bfd) (dormant)\n" +
+		"198.51.100.0/24\n" +
+		"\tdev eth1\n" +
+		"203.0.113.0/24\trecursive 10.20.30.40\n" +
+		"static6:\n" +
+		"2001:db8::/48\n" +
+		"\tvia fe80::1%eth0\n"
+
+	expected := []StaticRoute{
+		{
+			Protocol:    "static4",
+			Network:     "10.0.0.0/8",
+			Destination: "blackhole",
+		},
+		{
+			Protocol:    "static4",
+			Network:     "192.0.2.0/24",
+			Destination: "unicast",
+			NextHops: []StaticRouteNextHop{
+				{Gateway: "10.151.104.1", BFD: true},
+				{Gateway: "10.151.104.2", Onlink: true, BFD: true, Dormant: true},
+			},
+		},
+		{
+			Protocol:    "static4",
+			Network:     "198.51.100.0/24",
+			Destination: "unicast",
+			NextHops: []StaticRouteNextHop{
+				{Interface: "eth1"},
+			},
+		},
+		{
+			Protocol:    "static4",
+			Network:     "203.0.113.0/24",
+			Destination: "recursive",
+			NextHops: []StaticRouteNextHop{
+				{Gateway: "10.20.30.40"},
+			},
+		},
+		{
+			Protocol:    "static6",
+			Network:     "2001:db8::/48",
+			Destination: "unicast",
+			NextHops: []StaticRouteNextHop{
+				{Gateway: "fe80::1", Interface: "eth0"},
+			},
+		},
+	}
+
+	result := ParseStaticRoutes(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseStaticRoutes() = %+v, want %+v", result, expected)
+	}
+}
@@ -0,0 +1,58 @@
+package birdparse
+
+import (
+	"regexp"
+	"strings"
+)
+
+func ParseStatus(data string) Status {
+	result := Status{}
+
+	lines := strings.Split(data, "\n")
+
+	for _, line := range lines {
+		line = strings.TrimSpace(strings.TrimRight(line, "\r"))
+
+		if strings.HasSuffix(line, " ready.") ||
+			strings.HasPrefix(line, "Access restricted") {
+			continue
+		}
+
+		if m := regexp.MustCompile(`^BIRD\s+(\S+)$`).FindStringSubmatch(line); m != nil {
+			result.Version = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^Router ID is\s+(\S+)$`).FindStringSubmatch(line); m != nil {
+			result.RouterID = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^Hostname is\s+(\S+)$`).FindStringSubmatch(line); m != nil {
+			result.Hostname = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^Current server time is\s+(.*)$`).FindStringSubmatch(line); m != nil {
+			result.ServerTime = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^Last reboot on\s+(.*)$`).FindStringSubmatch(line); m != nil {
+			result.LastReboot = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^Last reconfiguration on\s+(.*)$`).FindStringSubmatch(line); m != nil {
+			result.LastReconfiguration = m[1]
+			continue
+		}
+
+		if m := regexp.MustCompile(`^Daemon is\s+(.*)$`).FindStringSubmatch(line); m != nil {
+			result.State = m[1]
+			continue
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,15 @@
+package birdparse
+
+type Status struct {
+	Version             string `json:"version"`
+	RouterID            string `json:"router_id"`
+	Hostname            string `json:"hostname"`
+	ServerTime          string `json:"server_time"`
+	LastReboot          string `json:"last_reboot"`
+	LastReconfiguration string `json:"last_reconfiguration"`
+	State               string `json:"state"`
+}
+
+func (s Status) IsUp() bool {
+	return s.State == "up and running"
+}
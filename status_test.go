@@ -0,0 +1,37 @@
+package birdparse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseStatus(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+BIRD 2.17.1
+Router ID is 192.0.2.1
+Hostname is rs1.example.net
+Current server time is 2026-01-16 12:00:00.123
+Last reboot on 2026-01-10 08:30:00.000
+Last reconfiguration on 2026-01-15 22:10:05.456
+Daemon is up and running`
+
+	expected := Status{
+		Version:             "2.17.1",
+		RouterID:            "192.0.2.1",
+		Hostname:            "rs1.example.net",
+		ServerTime:          "2026-01-16 12:00:00.123",
+		LastReboot:          "2026-01-10 08:30:00.000",
+		LastReconfiguration: "2026-01-15 22:10:05.456",
+		State:               "up and running",
+	}
+
+	result := ParseStatus(data)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseStatus() = %+v, want %+v", result, expected)
+	}
+
+	if !result.IsUp() {
+		t.Errorf("IsUp() = false, want true")
+	}
+}
@@ -0,0 +1,67 @@
+package birdparse
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+func ParseVPNRoutes(data string) []VPNRoute {
+	routes := []VPNRoute{}
+
+	for _, route := range ParseRoutes(data) {
+		fields := strings.Fields(route.Network)
+		if len(fields) != 2 {
+			continue
+		}
+
+		rd, ok := ParseRouteDistinguisher(fields[0])
+		if !ok {
+			continue
+		}
+
+		vpnRoute := VPNRoute{
+			Route:  route,
+			RD:     rd,
+			Prefix: fields[1],
+		}
+
+		if len(vpnRoute.MPLSLabels) == 0 {
+			if value, ok := route.Attribute("BGP.mpls_label_stack"); ok {
+				vpnRoute.MPLSLabels = parseMPLSLabels(value)
+			}
+		}
+
+		if value, ok := route.Attribute("BGP.ext_community"); ok {
+			for _, m := range regexp.MustCompile(`\(rt,\s*([^,\s]+),\s*(\d+)\)`).FindAllStringSubmatch(value, -1) {
+				vpnRoute.RouteTargets = append(vpnRoute.RouteTargets, m[1]+":"+m[2])
+			}
+		}
+
+		routes = append(routes, vpnRoute)
+	}
+
+	return routes
+}
+
+func ParseRouteDistinguisher(s string) (RouteDistinguisher, bool) {
+	if m := regexp.MustCompile(`^2:(\d+):(\d+)$`).FindStringSubmatch(s); m != nil {
+		return RouteDistinguisher{Type: 2, Administrator: m[1], Assigned: atoi(m[2])}, true
+	}
+
+	if m := regexp.MustCompile(`^(\d+\.\d+\.\d+\.\d+):(\d+)$`).FindStringSubmatch(s); m != nil {
+		return RouteDistinguisher{Type: 1, Administrator: m[1], Assigned: atoi(m[2])}, true
+	}
+
+	if m := regexp.MustCompile(`^(\d+):(\d+)$`).FindStringSubmatch(s); m != nil {
+		admin, _ := strconv.ParseUint(m[1], 10, 32)
+		assigned, _ := strconv.ParseUint(m[2], 10, 32)
+
+		if admin > 0xffff {
+			return RouteDistinguisher{Type: 2, Administrator: m[1], Assigned: int(assigned)}, true
+		}
+		return RouteDistinguisher{Type: 0, Administrator: m[1], Assigned: int(assigned)}, true
+	}
+
+	return RouteDistinguisher{}, false
+}
@@ -0,0 +1,24 @@
+package birdparse
+
+import "fmt"
+
+type RouteDistinguisher struct {
+	Type          int    `json:"type"`
+	Administrator string `json:"administrator"`
+	Assigned      int    `json:"assigned"`
+}
+
+func (rd RouteDistinguisher) String() string {
+	if rd.Type == 2 && atoi(rd.Administrator) <= 0xffff {
+		return fmt.Sprintf("2:%s:%d", rd.Administrator, rd.Assigned)
+	}
+
+	return fmt.Sprintf("%s:%d", rd.Administrator, rd.Assigned)
+}
+
+type VPNRoute struct {
+	Route
+	RD           RouteDistinguisher `json:"rd"`
+	Prefix       string             `json:"prefix"`
+	RouteTargets []string           `json:"route_targets"`
+}
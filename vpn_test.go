@@ -0,0 +1,66 @@
+package birdparse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseVPNRoutes(t *testing.T) {
+	data := `BIRD 2.17.1 ready.
+Table vpntab4:
+65000:100 10.10.0.0/24 unicast [ibgp_pe2 2026-01-16 from 10.255.0.2] * (100/20) [i]
+        via 10.1.0.2 on eth1 mpls 16/24001
+        Type: BGP univ
+        BGP.origin: IGP
+        BGP.as_path: 
+        BGP.next_hop: 10.255.0.2
+        BGP.local_pref: 100
+        BGP.ext_community: (rt, 65000, 100) (rt, 10.255.0.1, 7)
+10.255.0.2:7 10.20.0.0/16 unicast [ibgp_pe2 2026-01-16 from 10.255.0.2] * (100/20) [i]
+        via 10.1.0.2 on eth1
+        Type: BGP univ
+        BGP.mpls_label_stack: 24002
+2:65000:1 10.30.0.0/16 unicast [ibgp_pe2 2026-01-16] * (100/20) [i]
+        via 10.1.0.2 on eth1 mpls 24003
+        Type: BGP univ
+10.40.0.0/16         unicast [ospf1 2026-01-16] * (150/20) [10.255.0.2]
+        via 10.1.0.2 on eth1`
+
+	result := ParseVPNRoutes(data)
+
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 routes, got %d", len(result))
+	}
+
+	expectedRDs := []RouteDistinguisher{
+		{Type: 0, Administrator: "65000", Assigned: 100},
+		{Type: 1, Administrator: "10.255.0.2", Assigned: 7},
+		{Type: 2, Administrator: "65000", Assigned: 1},
+	}
+	expectedRDStrings := []string{"65000:100", "10.255.0.2:7", "2:65000:1"}
+	expectedPrefixes := []string{"10.10.0.0/24", "10.20.0.0/16", "10.30.0.0/16"}
+	expectedLabels := [][]int{{16, 24001}, {24002}, {24003}}
+
+	for i, route := range result {
+		if !reflect.DeepEqual(route.RD, expectedRDs[i]) {
+			t.Errorf("Route %d RD = %+v, want %+v", i, route.RD, expectedRDs[i])
+		}
+		if route.RD.String() != expectedRDStrings[i] {
+			t.Errorf("Route %d RD.String() = %q, want %q", i, route.RD.String(), expectedRDStrings[i])
+		}
+		if route.Prefix != expectedPrefixes[i] {
+			t.Errorf("Route %d Prefix = %q, want %q", i, route.Prefix, expectedPrefixes[i])
+		}
+		if !reflect.DeepEqual(route.MPLSLabels, expectedLabels[i]) {
+			t.Errorf("Route %d MPLSLabels = %v, want %v", i, route.MPLSLabels, expectedLabels[i])
+		}
+	}
+
+	if !reflect.DeepEqual(result[0].RouteTargets, []string{"65000:100", "10.255.0.1:7"}) {
+		t.Errorf("RouteTargets = %v", result[0].RouteTargets)
+	}
+
+	if result[0].Gateway != "10.1.0.2" || result[0].FromAddress != "10.255.0.2" || result[0].IGPMetric != 20 {
+		t.Errorf("Route 0 = %+v", result[0].Route)
+	}
+}
@@ -0,0 +1,179 @@
+package birdparse
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type Watcher struct {
+	Network      string
+	Address      string
+	Interval     time.Duration
+	RouteQueries []string
+	Concurrency  int
+	MinBackoff   time.Duration
+	MaxBackoff   time.Duration
+}
+
+func (w *Watcher) Watch(ctx context.Context) <-chan WatchUpdate {
+	updates := make(chan WatchUpdate)
+	go w.run(ctx, updates)
+	return updates
+}
+
+func (w *Watcher) run(ctx context.Context, updates chan<- WatchUpdate) {
+	defer close(updates)
+
+	detector := NewBgpEventDetector(nil)
+	previous := make(map[string][]Route)
+	initial := true
+	failures := 0
+
+	for {
+		wait := w.interval()
+
+		outputs, err := w.poll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			failures++
+			wait = w.backoff(failures)
+
+			if !sendWatchUpdate(ctx, updates, WatchUpdate{Time: time.Now(), Err: err}) {
+				return
+			}
+		} else {
+			failures = 0
+
+			update := WatchUpdate{
+				Time:      time.Now(),
+				Protocols: ParseBGPProtocols(outputs[0]),
+			}
+			update.Events = detector.Update(update.Protocols)
+			changed := initial || len(update.Events) > 0
+
+			for i, query := range w.RouteQueries {
+				routes := ParseRoutes(outputs[i+1])
+				diff := DiffRoutes(previous[query], routes)
+				previous[query] = routes
+
+				changed = changed || !diff.IsEmpty()
+				update.Routes = append(update.Routes, WatchRouteUpdate{
+					Query:  query,
+					Routes: routes,
+					Diff:   diff,
+				})
+			}
+
+			initial = false
+
+			if changed && !sendWatchUpdate(ctx, updates, update) {
+				return
+			}
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (w *Watcher) poll(ctx context.Context) ([]string, error) {
+	commands := append([]string{"show protocols all"}, w.RouteQueries...)
+	outputs := make([]string, len(commands))
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	jobs := make(chan int, len(commands))
+	for i := range commands {
+		jobs <- i
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	for range min(w.concurrency(), len(commands)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			client, err := Dial(ctx, w.network(), w.Address)
+			if err != nil {
+				cancel(err)
+				return
+			}
+			defer client.Close()
+
+			for i := range jobs {
+				output, err := client.Query(ctx, commands[i])
+				if err != nil {
+					cancel(fmt.Errorf("%s: %w", commands[i], err))
+					return
+				}
+				outputs[i] = output
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := context.Cause(ctx); err != nil {
+		return nil, err
+	}
+
+	return outputs, nil
+}
+
+func (w *Watcher) network() string {
+	if w.Network == "" {
+		return "unix"
+	}
+	return w.Network
+}
+
+func (w *Watcher) interval() time.Duration {
+	if w.Interval <= 0 {
+		return 30 * time.Second
+	}
+	return w.Interval
+}
+
+func (w *Watcher) concurrency() int {
+	if w.Concurrency <= 0 {
+		return 1
+	}
+	return w.Concurrency
+}
+
+func (w *Watcher) backoff(failures int) time.Duration {
+	minBackoff, maxBackoff := w.MinBackoff, w.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = time.Second
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = time.Minute
+	}
+
+	backoff := minBackoff
+	for i := 1; i < failures && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxBackoff)
+}
+
+func sendWatchUpdate(ctx context.Context, updates chan<- WatchUpdate, update WatchUpdate) bool {
+	select {
+	case updates <- update:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
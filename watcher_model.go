@@ -0,0 +1,17 @@
+package birdparse
+
+import "time"
+
+type WatchRouteUpdate struct {
+	Query  string    `json:"query"`
+	Routes []Route   `json:"routes"`
+	Diff   RouteDiff `json:"diff"`
+}
+
+type WatchUpdate struct {
+	Time      time.Time          `json:"time"`
+	Protocols []BgpProtocol      `json:"protocols"`
+	Events    []BgpEvent         `json:"events"`
+	Routes    []WatchRouteUpdate `json:"routes"`
+	Err       error              `json:"-"`
+}
@@ -0,0 +1,111 @@
+package birdparse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LaunchPad-Network/birdparse/internal/birdtest"
+)
+
+func TestWatcher(t *testing.T) {
+	server := birdtest.NewServer(t)
+	server.Handle("show protocols all", `BIRD 2.17.1 ready.
+peer_a     BGP        ---        start  2026-01-16    Active
+  BGP state:          Active
+    Neighbor address: 192.0.2.1
+    Neighbor AS:      64500`, `BIRD 2.17.1 ready.
+peer_a     BGP        ---        up     12:00:00.000  Established
+  BGP state:          Established
+    Neighbor address: 192.0.2.1
+    Neighbor AS:      64500`)
+	server.Handle("show route all", `Table master4:
+10.0.0.0/8           unicast [static4 2026-01-16] * (200)
+        via 10.151.104.1 on eth0`, `Table master4:
+10.0.0.0/8           unicast [static4 2026-01-16] * (200)
+        via 10.151.104.1 on eth0
+192.0.2.0/24         unicast [static4 2026-01-16] * (200)
+        via 10.151.104.1 on eth0`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	watcher := &Watcher{
+		Network:      server.Network,
+		Address:      server.Address,
+		Interval:     10 * time.Millisecond,
+		RouteQueries: []string{"show route all"},
+		Concurrency:  2,
+	}
+
+	updates := watcher.Watch(ctx)
+
+	first := <-updates
+	if first.Err != nil {
+		t.Fatalf("first update error = %v", first.Err)
+	}
+	if len(first.Protocols) != 1 || first.Protocols[0].BgpState != "Active" {
+		t.Errorf("first update protocols = %+v", first.Protocols)
+	}
+	if len(first.Routes) != 1 || len(first.Routes[0].Routes) != 1 {
+		t.Errorf("first update routes = %+v", first.Routes)
+	}
+
+	second := <-updates
+	if second.Err != nil {
+		t.Fatalf("second update error = %v", second.Err)
+	}
+
+	var up bool
+	for _, event := range second.Events {
+		if event.Type == BgpEventSessionUp && event.Protocol == "peer_a" {
+			up = true
+		}
+	}
+	if !up {
+		t.Errorf("second update events = %+v, want session up", second.Events)
+	}
+
+	if added := second.Routes[0].Diff.AddedPrefixes; len(added) != 1 || added[0] != "192.0.2.0/24" {
+		t.Errorf("second update added prefixes = %v, want [192.0.2.0/24]", added)
+	}
+
+	cancel()
+	for range updates {
+	}
+}
+
+func TestWatcherBackoff(t *testing.T) {
+	server := birdtest.NewServer(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	watcher := &Watcher{
+		Network:    server.Network,
+		Address:    server.Address,
+		Interval:   time.Hour,
+		MinBackoff: 10 * time.Millisecond,
+		MaxBackoff: 20 * time.Millisecond,
+	}
+
+	updates := watcher.Watch(ctx)
+
+	for i := 0; i < 3; i++ {
+		update := <-updates
+
+		var birdErr *BirdError
+		if !errors.As(update.Err, &birdErr) {
+			t.Fatalf("update %d error = %v, want BirdError", i, update.Err)
+		}
+	}
+
+	if got := watcher.backoff(5); got != 20*time.Millisecond {
+		t.Errorf("backoff(5) = %v, want %v", got, 20*time.Millisecond)
+	}
+
+	cancel()
+	for range updates {
+	}
+}